@@ -5,4 +5,16 @@ const (
 	// highlighted flag on the product in the exported table data.
 	// Valid values are `"true"` and `true`.
 	AnnotationHighlightedProduct = "comparisonapis/products/highlighted"
+	// AnnotationCampaign is the marketing campaign the product belongs to.
+	// Valid values are non-empty strings.
+	AnnotationCampaign = "comparisonapis/products/campaign"
+	// AnnotationPartner is the partner that supplied the product.
+	// Valid values are non-empty strings.
+	AnnotationPartner = "comparisonapis/products/partner"
+	// AnnotationValidFrom is when the product becomes available.
+	// Valid values are RFC 3339 timestamps.
+	AnnotationValidFrom = "comparisonapis/products/valid-from"
+	// AnnotationValidUntil is when the product stops being available.
+	// Valid values are RFC 3339 timestamps after AnnotationValidFrom.
+	AnnotationValidUntil = "comparisonapis/products/valid-until"
 )
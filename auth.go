@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -18,44 +19,58 @@ type StaticToken string
 type PasswordAuthentication struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	mu       sync.Mutex
 	ttl      time.Time
 	token    string
 }
 
 func (a *PasswordAuthentication) Token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
 	if a.ttl.After(time.Now()) {
 		return a.token, nil
 	}
 
+	err := a.refresh(ctx)
+	authRefreshed(ctx, err)
+	if err != nil {
+		return "", err
+	}
+
+	return a.token, nil
+}
+
+func (a *PasswordAuthentication) refresh(ctx context.Context) error {
 	rb, _ := json.Marshal(a)
-	req, _ := http.NewRequest("POST", DefaultBaseURL+"/auth", bytes.NewReader(rb))
+	req, _ := http.NewRequestWithContext(ctx, "POST", DefaultBaseURL+"/auth", bytes.NewReader(rb))
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("unable to get response from auth %w", err)
+		return fmt.Errorf("unable to get response from auth %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", ErrAuthorizationFailed
+		return ErrAuthorizationFailed
 	}
 
 	rb, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("unable to read response from auth %w", err)
+		return fmt.Errorf("unable to read response from auth %w", err)
 	}
 
 	var data struct {
 		Token string `json:"token"`
 	}
 	if err := json.Unmarshal(rb, &data); err != nil {
-		return "", fmt.Errorf("malformed response from auth %w", err)
+		return fmt.Errorf("malformed response from auth %w", err)
 	}
 
 	a.token = data.Token
 	a.ttl = time.Now().Add(time.Hour)
 
-	return a.token, nil
+	return nil
 }
 
 func (a *PasswordAuthentication) AuthorizeRequest(ctx context.Context, req *http.Request) error {
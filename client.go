@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -25,7 +27,15 @@ type (
 		base              string
 		authProvider      AuthProvider
 		logError          func(error)
+		logger            *slog.Logger
+		logLevels         LogLevels
 		requestMiddleware RequestMiddleware
+		observers         []Observer
+		tracer            Tracer
+		limiter           *rateLimiter
+		validate          bool
+		enumMode          EnumMode
+		groupEditAttempts int
 	}
 
 	// Option customises the client.
@@ -38,7 +48,10 @@ func New(opts ...Option) (*Client, error) {
 		httpC:             http.DefaultClient,
 		base:              DefaultBaseURL,
 		logError:          func(error) {},
+		logLevels:         DefaultLogLevels,
 		requestMiddleware: RequestMiddleware{},
+		tracer:            OpenCensusTracer,
+		groupEditAttempts: DefaultGroupEditAttempts,
 	}
 
 	for _, opt := range opts {
@@ -47,6 +60,10 @@ func New(opts ...Option) (*Client, error) {
 		}
 	}
 
+	if c.logger != nil {
+		c.observers = append(c.observers, &logObserver{l: c.logger, levels: c.logLevels})
+	}
+
 	return c, nil
 }
 
@@ -90,17 +107,21 @@ func WithRequestMiddleware(m RequestMiddlewareFunc) Option {
 	}
 }
 
-func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
-	// @todo pass context to this method :)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// newRequest will create an authorized request for the route, the {param}
+// placeholders of the route are replaced in order with the escaped params
+// and an encoded query string may follow the route.
+func (c *Client) newRequest(ctx context.Context, method, route string, body io.Reader, params ...string) (*http.Request, error) {
+	tmpl, _, _ := strings.Cut(route, "?")
 
-	req, err := http.NewRequest(method, c.base+path, body)
+	req, err := http.NewRequestWithContext(withRoute(ctx, tmpl), method, c.base+expandRoute(route, params), body)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := c.authProvider.AuthorizeRequest(ctx, req); err != nil {
+	actx, cancel := context.WithTimeout(withAuthRefreshHook(ctx, c.authRefreshed), 5*time.Second)
+	defer cancel()
+
+	if err := c.authProvider.AuthorizeRequest(actx, req); err != nil {
 		return nil, fmt.Errorf("authorization error %w", err)
 	}
 
@@ -109,5 +130,55 @@ func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request,
 
 // Do forwards the request to be handled by the HTTP client provided.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	return c.httpC.Do(c.requestMiddleware.Apply(req))
+	ev := &RequestEvent{
+		Request: req,
+		Route:   routeFromRequest(req),
+		Attempt: 1,
+	}
+
+	ctx := req.Context()
+	span := spanFromContext(ctx)
+	span.SetAttribute(AttributeHTTPMethod, req.Method)
+	span.SetAttribute(AttributeURLFull, req.URL.Redacted())
+	span.SetAttribute(AttributeURLTemplate, ev.Route)
+	span.SetAttribute(AttributeServerAddress, req.URL.Hostname())
+
+	for _, o := range c.observers {
+		ctx = o.RequestStarted(ctx, ev)
+	}
+
+	req = c.requestMiddleware.Apply(req.WithContext(ctx))
+	ev.Request = req
+
+	start := time.Now()
+	res, err := c.send(req, ev)
+	ev.Response, ev.Err, ev.Elapsed = res, err, time.Since(start)
+
+	if err != nil {
+		span.SetError(err)
+	} else {
+		span.SetAttribute(AttributeHTTPStatusCode, res.StatusCode)
+		if err := statusCodeToError(res.StatusCode); err != nil {
+			span.SetError(err)
+		}
+	}
+
+	for _, o := range c.observers {
+		o.RequestFinished(ctx, ev)
+	}
+
+	return res, err
+}
+
+func expandRoute(route string, params []string) string {
+	for _, p := range params {
+		start := strings.IndexByte(route, '{')
+		end := strings.IndexByte(route, '}')
+		if start < 0 || end < start {
+			break
+		}
+		route = route[:start] + url.PathEscape(p) + route[end+1:]
+	}
+
+	return route
 }
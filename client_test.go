@@ -0,0 +1,25 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteParamsAreEscaped(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c, _ := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+
+	_, err := c.FindGroup(context.Background(), "abc/../123?x")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, "/v1/groups/abc%2F..%2F123%3Fx", path)
+}
@@ -0,0 +1,158 @@
+// Package eligibility matches products against the circumstances of a
+// customer and explains why products were excluded.
+package eligibility
+
+import (
+	"errors"
+	"fmt"
+
+	capis "lwebco.de/go-capis"
+)
+
+// Borrower is who the product is for.
+type Borrower int
+
+const (
+	// BorrowerAny matches consumer and commercial products.
+	BorrowerAny Borrower = iota
+	// BorrowerConsumer matches products for consumers.
+	BorrowerConsumer
+	// BorrowerCommercial matches products for businesses.
+	BorrowerCommercial
+)
+
+// Code identifies why a product was excluded.
+type Code string
+
+const (
+	// CodeInactive the product is not active.
+	CodeInactive Code = "inactive"
+	// CodeBrokerOnly the product is only available through a broker.
+	CodeBrokerOnly Code = "broker_only"
+	// CodeNotConsumer the product is not available to consumers.
+	CodeNotConsumer Code = "not_consumer"
+	// CodeNotCommercial the product is not available to businesses.
+	CodeNotCommercial Code = "not_commercial"
+	// CodeAmountBelowMinimum the amount is less than the product minimum.
+	CodeAmountBelowMinimum Code = "amount_below_minimum"
+	// CodeAmountAboveMaximum the amount is more than the product maximum.
+	CodeAmountAboveMaximum Code = "amount_above_maximum"
+	// CodeTermBelowMinimum the term is shorter than the product minimum.
+	CodeTermBelowMinimum Code = "term_below_minimum"
+	// CodeTermAboveMaximum the term is longer than the product maximum.
+	CodeTermAboveMaximum Code = "term_above_maximum"
+	// CodeLoanToValue the loan to value is more than the product maximum.
+	CodeLoanToValue Code = "loan_to_value"
+	// CodeFeeTooHigh the fee costs more than the criteria allow.
+	CodeFeeTooHigh Code = "fee_too_high"
+	// CodeNotISA the product is not an ISA.
+	CodeNotISA Code = "not_isa"
+	// CodeCurrencyMismatch the product is in a different currency.
+	CodeCurrencyMismatch Code = "currency_mismatch"
+)
+
+type (
+	// Reason is a single reason a product was excluded.
+	Reason struct {
+		Code    Code
+		Message string
+	}
+
+	// Exclusion is a product that did not match and the reasons why.
+	Exclusion struct {
+		ID      string
+		Reasons []*Reason
+	}
+
+	// checker collects the reasons a product does not match.
+	checker struct {
+		reasons []*Reason
+	}
+)
+
+func (r *Reason) String() string {
+	return string(r.Code) + ": " + r.Message
+}
+
+func (c *checker) add(code Code, format string, args ...interface{}) {
+	c.reasons = append(c.reasons, &Reason{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) listing(active, brokerOnly, includeInactive, includeBrokerOnly bool) {
+	if !active && !includeInactive {
+		c.add(CodeInactive, "product is not active")
+	}
+	if brokerOnly && !includeBrokerOnly {
+		c.add(CodeBrokerOnly, "product is only available through a broker")
+	}
+}
+
+func (c *checker) borrower(b Borrower, consumer, commercial bool) {
+	switch {
+	case b == BorrowerConsumer && !consumer:
+		c.add(CodeNotConsumer, "product is not available to consumers")
+	case b == BorrowerCommercial && !commercial:
+		c.add(CodeNotCommercial, "product is not available to businesses")
+	}
+}
+
+// amount checks the amount is within the range, unset values are not checked.
+func (c *checker) amount(amount, min, max capis.Money) {
+	if amount.Amount == 0 {
+		return
+	}
+
+	if min.Amount > 0 {
+		if n, err := amount.Cmp(min); err != nil {
+			c.add(CodeCurrencyMismatch, "%s is not in %s", amount, min.Currency)
+			return
+		} else if n < 0 {
+			c.add(CodeAmountBelowMinimum, "%s is less than the minimum of %s", amount, min)
+		}
+	}
+
+	if max.Amount > 0 {
+		if n, err := amount.Cmp(max); err != nil {
+			c.add(CodeCurrencyMismatch, "%s is not in %s", amount, max.Currency)
+		} else if n > 0 {
+			c.add(CodeAmountAboveMaximum, "%s is more than the maximum of %s", amount, max)
+		}
+	}
+}
+
+// term checks the term is within the range, unset values are not checked.
+func (c *checker) term(term, min, max capis.Months) {
+	if term.Value == 0 {
+		return
+	}
+
+	if min.Value > 0 && term.Value < min.Value {
+		c.add(CodeTermBelowMinimum, "%d months is less than the minimum of %d", term.Value, min.Value)
+	}
+	if max.Value > 0 && term.Value > max.Value {
+		c.add(CodeTermAboveMaximum, "%d months is more than the maximum of %d", term.Value, max.Value)
+	}
+}
+
+// fee checks the cost of the fee for the amount is at most max, it is not
+// checked without an amount.
+func (c *checker) fee(field string, fee capis.Fee, amount capis.Money, max *capis.Money) {
+	if max == nil || amount.Amount == 0 {
+		return
+	}
+
+	cost, err := fee.CostWith(amount, capis.FeeCostOptions{Rounding: capis.RoundUp})
+	if errors.Is(err, capis.ErrInvalidPercent) {
+		c.add(CodeFeeTooHigh, "%s cannot be costed: %v", field, err)
+		return
+	} else if err != nil {
+		c.add(CodeCurrencyMismatch, "%s is not in %s", field, amount.Currency)
+		return
+	}
+
+	if n, err := cost.Cmp(*max); err != nil {
+		c.add(CodeCurrencyMismatch, "%s is not in %s", field, max.Currency)
+	} else if n > 0 {
+		c.add(CodeFeeTooHigh, "%s of %s is more than %s", field, cost, max)
+	}
+}
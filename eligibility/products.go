@@ -0,0 +1,152 @@
+package eligibility
+
+import (
+	capis "lwebco.de/go-capis"
+)
+
+type (
+	// MortgageCriteria are the circumstances to match mortgages against,
+	// zero values are not checked.
+	MortgageCriteria struct {
+		LoanAmount    capis.Money
+		PropertyValue capis.Money
+		Term          capis.Months
+		Borrower      Borrower
+		// MaxFee is the most the fee can cost for the loan amount.
+		MaxFee            *capis.Money
+		IncludeBrokerOnly bool
+		IncludeInactive   bool
+	}
+
+	// MortgageResult are the mortgages that matched and the exclusions.
+	MortgageResult struct {
+		Matches  []*capis.Mortgage
+		Excluded []*Exclusion
+	}
+
+	// LoanCriteria are the circumstances to match loans against, zero
+	// values are not checked.
+	LoanCriteria struct {
+		Amount   capis.Money
+		Term     capis.Months
+		Borrower Borrower
+		// MaxSetupFee is the most the setup fee can cost for the amount.
+		MaxSetupFee       *capis.Money
+		IncludeBrokerOnly bool
+		IncludeInactive   bool
+	}
+
+	// LoanResult are the loans that matched and the exclusions.
+	LoanResult struct {
+		Matches  []*capis.Loan
+		Excluded []*Exclusion
+	}
+
+	// BankAccountCriteria are the circumstances to match bank accounts
+	// against, zero values are not checked.
+	BankAccountCriteria struct {
+		Deposit capis.Money
+		// ISA only matches bank accounts that are ISAs.
+		ISA               bool
+		IncludeBrokerOnly bool
+		IncludeInactive   bool
+	}
+
+	// BankAccountResult are the bank accounts that matched and the exclusions.
+	BankAccountResult struct {
+		Matches  []*capis.BankAccount
+		Excluded []*Exclusion
+	}
+)
+
+// Check returns the reasons the mortgage does not match, nil when it does.
+func (c *MortgageCriteria) Check(m *capis.Mortgage) []*Reason {
+	ch := &checker{}
+	ch.listing(m.Active, m.BrokerOnly, c.IncludeInactive, c.IncludeBrokerOnly)
+	ch.borrower(c.Borrower, m.IsConsumer, m.IsCommercial)
+	ch.amount(c.LoanAmount, m.MinimumLoan, m.MaximumLoan)
+	ch.term(c.Term, m.MinimumTerm, m.MaximumTerm)
+	ch.fee("fee", m.Fee, c.LoanAmount, c.MaxFee)
+
+	if c.LoanAmount.Amount > 0 && c.PropertyValue.Amount > 0 && m.LoanToValue.Value > 0 {
+		if _, err := c.LoanAmount.Cmp(c.PropertyValue); err != nil {
+			ch.add(CodeCurrencyMismatch, "property value is not in %s", c.LoanAmount.Currency)
+		} else if ltv := float64(c.LoanAmount.Amount) / float64(c.PropertyValue.Amount) * 100; ltv > m.LoanToValue.Value {
+			ch.add(CodeLoanToValue, "%.2f%% is more than the maximum of %.2f%%", ltv, m.LoanToValue.Value)
+		}
+	}
+
+	return ch.reasons
+}
+
+// Match returns the mortgages that match the criteria, keeping their order.
+func (c *MortgageCriteria) Match(mortgages []*capis.Mortgage) *MortgageResult {
+	res := &MortgageResult{Matches: make([]*capis.Mortgage, 0, len(mortgages))}
+
+	for _, m := range mortgages {
+		if reasons := c.Check(m); len(reasons) > 0 {
+			res.Excluded = append(res.Excluded, &Exclusion{ID: m.ID, Reasons: reasons})
+			continue
+		}
+		res.Matches = append(res.Matches, m)
+	}
+
+	return res
+}
+
+// Check returns the reasons the loan does not match, nil when it does.
+func (c *LoanCriteria) Check(l *capis.Loan) []*Reason {
+	ch := &checker{}
+	ch.listing(l.Active, l.BrokerOnly, c.IncludeInactive, c.IncludeBrokerOnly)
+	ch.borrower(c.Borrower, l.IsConsumer, l.IsCommercial)
+	ch.amount(c.Amount, l.MinimumLoan, l.MaximumLoan)
+	ch.term(c.Term, l.MinimumTerm, l.MaximumTerm)
+	ch.fee("setup fee", l.SetupFee, c.Amount, c.MaxSetupFee)
+
+	return ch.reasons
+}
+
+// Match returns the loans that match the criteria, keeping their order.
+func (c *LoanCriteria) Match(loans []*capis.Loan) *LoanResult {
+	res := &LoanResult{Matches: make([]*capis.Loan, 0, len(loans))}
+
+	for _, l := range loans {
+		if reasons := c.Check(l); len(reasons) > 0 {
+			res.Excluded = append(res.Excluded, &Exclusion{ID: l.ID, Reasons: reasons})
+			continue
+		}
+		res.Matches = append(res.Matches, l)
+	}
+
+	return res
+}
+
+// Check returns the reasons the bank account does not match, nil when it
+// does.
+func (c *BankAccountCriteria) Check(b *capis.BankAccount) []*Reason {
+	ch := &checker{}
+	ch.listing(b.Active, b.BrokerOnly, c.IncludeInactive, c.IncludeBrokerOnly)
+	ch.amount(c.Deposit, b.MinimumDeposit, b.MaximumDeposit)
+
+	if c.ISA && !b.IsISA {
+		ch.add(CodeNotISA, "product is not an ISA")
+	}
+
+	return ch.reasons
+}
+
+// Match returns the bank accounts that match the criteria, keeping their
+// order.
+func (c *BankAccountCriteria) Match(accounts []*capis.BankAccount) *BankAccountResult {
+	res := &BankAccountResult{Matches: make([]*capis.BankAccount, 0, len(accounts))}
+
+	for _, b := range accounts {
+		if reasons := c.Check(b); len(reasons) > 0 {
+			res.Excluded = append(res.Excluded, &Exclusion{ID: b.ID, Reasons: reasons})
+			continue
+		}
+		res.Matches = append(res.Matches, b)
+	}
+
+	return res
+}
@@ -0,0 +1,88 @@
+package eligibility
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	capis "lwebco.de/go-capis"
+)
+
+func codes(reasons []*Reason) []Code {
+	out := make([]Code, len(reasons))
+	for i, r := range reasons {
+		out[i] = r.Code
+	}
+	return out
+}
+
+func TestMortgageCriteria(t *testing.T) {
+	base := capis.Mortgage{
+		ID:           "ok",
+		Active:       true,
+		IsConsumer:   true,
+		MinimumLoan:  capis.NewMoney("GBP", 2500000, ""),
+		MaximumLoan:  capis.NewMoney("GBP", 50000000, ""),
+		MinimumTerm:  capis.NewMonths(60, ""),
+		MaximumTerm:  capis.NewMonths(420, ""),
+		LoanToValue:  capis.NewRate(75, ""),
+		Fee:          capis.NewVariableFee(1, ""),
+		IsCommercial: false,
+	}
+
+	inactive, broker, ltv := base, base, base
+	inactive.ID, inactive.Active = "inactive", false
+	broker.ID, broker.BrokerOnly = "broker", true
+	ltv.ID, ltv.LoanToValue = "ltv", capis.NewRate(60, "")
+
+	maxFee := capis.NewMoney("GBP", 250000, "")
+	c := &MortgageCriteria{
+		LoanAmount:    capis.NewMoney("GBP", 20000000, ""),
+		PropertyValue: capis.NewMoney("GBP", 28000000, ""),
+		Term:          capis.NewMonths(300, ""),
+		Borrower:      BorrowerConsumer,
+		MaxFee:        &maxFee,
+	}
+
+	res := c.Match([]*capis.Mortgage{&base, &inactive, &broker, &ltv})
+
+	assert.Len(t, res.Matches, 1)
+	assert.Equal(t, "ok", res.Matches[0].ID)
+	assert.Len(t, res.Excluded, 3)
+	assert.Equal(t, []Code{CodeInactive}, codes(res.Excluded[0].Reasons))
+	assert.Equal(t, []Code{CodeBrokerOnly}, codes(res.Excluded[1].Reasons))
+	assert.Equal(t, []Code{CodeLoanToValue}, codes(res.Excluded[2].Reasons))
+
+	c.LoanAmount = capis.NewMoney("GBP", 1000000, "")
+	c.Term = capis.NewMonths(480, "")
+	c.Borrower = BorrowerCommercial
+	assert.Equal(t, []Code{CodeNotCommercial, CodeAmountBelowMinimum, CodeTermAboveMaximum}, codes(c.Check(&base)))
+
+	c = &MortgageCriteria{LoanAmount: capis.NewMoney("GBP", 20000000, ""), MaxFee: &capis.Money{Currency: "GBP", Amount: 100000}}
+	assert.Equal(t, []Code{CodeFeeTooHigh}, codes(c.Check(&base)))
+
+	nan := base
+	nan.Fee = capis.NewVariableFee(math.NaN(), "")
+	c = &MortgageCriteria{LoanAmount: capis.NewMoney("GBP", 20000000, ""), MaxFee: &maxFee}
+	assert.Equal(t, []Code{CodeFeeTooHigh}, codes(c.Check(&nan)))
+
+	c = &MortgageCriteria{MaxFee: &maxFee}
+	assert.Empty(t, c.Check(&base), "the fee is not checked without a loan amount")
+
+	c = &MortgageCriteria{LoanAmount: capis.NewMoney("GBP", 20000000, ""), PropertyValue: capis.NewMoney("EUR", 10000000, "")}
+	assert.Equal(t, []Code{CodeCurrencyMismatch}, codes(c.Check(&base)))
+}
+
+func TestLoanAndBankAccountCriteria(t *testing.T) {
+	l := &capis.Loan{
+		Active:      true,
+		MaximumLoan: capis.NewMoney("GBP", 2500000, ""),
+	}
+	assert.Empty(t, (&LoanCriteria{Amount: capis.NewMoney("GBP", 1000000, "")}).Check(l))
+	assert.Equal(t, []Code{CodeAmountAboveMaximum}, codes((&LoanCriteria{Amount: capis.NewMoney("GBP", 3000000, "")}).Check(l)))
+	assert.Equal(t, []Code{CodeCurrencyMismatch}, codes((&LoanCriteria{Amount: capis.NewMoney("EUR", 1000000, "")}).Check(l)))
+
+	b := &capis.BankAccount{Active: true}
+	assert.Equal(t, []Code{CodeNotISA}, codes((&BankAccountCriteria{ISA: true}).Check(b)))
+}
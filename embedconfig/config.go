@@ -0,0 +1,101 @@
+// Package embedconfig manages embeds from definitions kept as code.
+package embedconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	capis "lwebco.de/go-capis"
+)
+
+// Format of a definitions file.
+type Format string
+
+const (
+	// FormatJSON definitions are JSON.
+	FormatJSON Format = "json"
+	// FormatYAML definitions are YAML.
+	FormatYAML Format = "yaml"
+)
+
+type (
+	// Config contains the embeds that should exist.
+	Config struct {
+		Embeds []*Definition `json:"embeds"`
+	}
+
+	// Definition is the desired state of an embed, keys match the JSON
+	// representation of the capis types in both formats.
+	Definition struct {
+		ID        string               `json:"id"`
+		Group     string               `json:"group_id"`
+		Filters   []string             `json:"filters"`
+		Columns   []string             `json:"columns"`
+		Theme     capis.EmbedTheme     `json:"theme"`
+		Overrides capis.EmbedOverrides `json:"overrides"`
+	}
+)
+
+// Load will read the definitions from the file, the format is chosen by
+// the file extension.
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	format := FormatJSON
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = FormatYAML
+	}
+
+	return Parse(b, format)
+}
+
+// Parse will decode the definitions in the format given.
+func Parse(b []byte, format Format) (*Config, error) {
+	if format == FormatYAML {
+		var v interface{}
+		if err := yaml.Unmarshal(b, &v); err != nil {
+			return nil, fmt.Errorf("malformed yaml definitions %w", err)
+		}
+
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("unable to convert yaml definitions %w", err)
+		}
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("malformed definitions %w", err)
+	}
+
+	return cfg, cfg.validate()
+}
+
+func (c *Config) validate() error {
+	seen := map[string]bool{}
+
+	for i, d := range c.Embeds {
+		if d.ID == "" {
+			return fmt.Errorf("embed %d has no id", i)
+		}
+		if seen[d.ID] {
+			return fmt.Errorf("embed %s is defined more than once", d.ID)
+		}
+		seen[d.ID] = true
+	}
+
+	return nil
+}
+
+func (d *Definition) createRequest() *capis.CreateEmbedRequest {
+	return capis.NewCreateEmbedRequestForGroup(d.ID, d.Theme, d.Overrides, d.Filters, d.Columns, d.Group)
+}
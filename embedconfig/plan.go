@@ -0,0 +1,299 @@
+package embedconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	capis "lwebco.de/go-capis"
+)
+
+// pageSize is the number of embeds listed per request.
+const pageSize = 100
+
+// Action is what applying a step will do to an embed.
+type Action string
+
+const (
+	// ActionCreate the embed does not exist.
+	ActionCreate Action = "create"
+	// ActionUpdate the embed differs from its definition.
+	ActionUpdate Action = "update"
+	// ActionNone the embed matches its definition.
+	ActionNone Action = "none"
+)
+
+type (
+	// Remote is the part of the capis client used to plan and apply.
+	Remote interface {
+		ListEmbeds(ctx context.Context, offset, limit int64, filters *capis.EmbedFilters) (*capis.ListEmbedsResponse, error)
+		CreateEmbed(ctx context.Context, embed *capis.CreateEmbedRequest) error
+		UpdateEmbed(ctx context.Context, euq *capis.EmbedUpdateRequest) error
+		UpdateEmbedApplyURL(ctx context.Context, emb *capis.Embed, newApplyURL string) error
+	}
+
+	// Change is a single field that differs from its definition.
+	Change struct {
+		Field string
+		From  string
+		To    string
+		// Immutable changes cannot be applied to an existing embed, it
+		// has to be recreated.
+		Immutable bool
+	}
+
+	// Step is the action needed for a single embed.
+	Step struct {
+		ID      string
+		Action  Action
+		Changes []Change
+
+		def     *Definition
+		current *capis.Embed
+	}
+
+	// Plan contains a step for each defined embed.
+	Plan struct {
+		Steps []*Step
+	}
+)
+
+// ErrImmutableChange is returned by Apply for changes it could not make.
+var ErrImmutableChange = errors.New("change requires the embed to be recreated")
+
+// NewPlan will compare the definitions with the existing embeds.
+func NewPlan(ctx context.Context, r Remote, cfg *Config) (*Plan, error) {
+	ids := make([]string, len(cfg.Embeds))
+	for i, d := range cfg.Embeds {
+		ids[i] = d.ID
+	}
+
+	existing, err := listEmbeds(ctx, r, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{}
+	for _, d := range cfg.Embeds {
+		s := &Step{ID: d.ID, def: d, current: existing[d.ID]}
+
+		switch {
+		case s.current == nil:
+			s.Action = ActionCreate
+		default:
+			s.Changes = diff(s.current, d)
+			s.Action = ActionNone
+			if len(s.Changes) > 0 {
+				s.Action = ActionUpdate
+			}
+		}
+
+		p.Steps = append(p.Steps, s)
+	}
+
+	return p, nil
+}
+
+func listEmbeds(ctx context.Context, r Remote, ids []string) (map[string]*capis.Embed, error) {
+	out := map[string]*capis.Embed{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	for offset := int64(0); ; offset += pageSize {
+		res, err := r.ListEmbeds(ctx, offset, pageSize, &capis.EmbedFilters{ID: ids})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list embeds %w", err)
+		}
+
+		for _, e := range res.Data {
+			out[e.ID] = e
+		}
+
+		if len(res.Data) < pageSize || (res.Pagination.Total > 0 && offset+pageSize >= res.Pagination.Total) {
+			return out, nil
+		}
+	}
+}
+
+// HasChanges reports if applying the plan would change any embed.
+func (p *Plan) HasChanges() bool {
+	for _, s := range p.Steps {
+		if s.Action != ActionNone {
+			return true
+		}
+	}
+	return false
+}
+
+// Print will write the plan in a human readable form.
+func (p *Plan) Print(w io.Writer) {
+	for _, s := range p.Steps {
+		switch s.Action {
+		case ActionCreate:
+			fmt.Fprintf(w, "+ create %s\n", s.ID)
+		case ActionUpdate:
+			fmt.Fprintf(w, "~ update %s\n", s.ID)
+			for _, c := range s.Changes {
+				suffix := ""
+				if c.Immutable {
+					suffix = " (requires recreating the embed)"
+				}
+				fmt.Fprintf(w, "    %s: %s => %s%s\n", c.Field, c.From, c.To, suffix)
+			}
+		}
+	}
+
+	if !p.HasChanges() {
+		fmt.Fprintln(w, "no changes, the embeds match their definitions")
+	}
+}
+
+// String returns the printed plan.
+func (p *Plan) String() string {
+	sb := &strings.Builder{}
+	p.Print(sb)
+	return sb.String()
+}
+
+// Apply will make the changes of the plan, the immutable changes are
+// skipped and returned as errors along with any failed step.
+func (p *Plan) Apply(ctx context.Context, r Remote) error {
+	var errs []error
+
+	for _, s := range p.Steps {
+		if err := s.apply(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("embed %s: %w", s.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Step) apply(ctx context.Context, r Remote) error {
+	if s.Action == ActionCreate {
+		return r.CreateEmbed(ctx, s.def.createRequest())
+	}
+	if s.Action != ActionUpdate {
+		return nil
+	}
+
+	var (
+		update, applyURL bool
+		immutable        []string
+	)
+	for _, c := range s.Changes {
+		switch {
+		case c.Immutable:
+			immutable = append(immutable, c.Field)
+		case c.Field == "overrides.apply_url":
+			applyURL = true
+		default:
+			update = true
+		}
+	}
+
+	if update {
+		euq := s.current.Update().
+			SetFilters(s.def.Filters).
+			SetColumns(s.def.Columns).
+			SetTheme(s.def.Theme)
+		if err := r.UpdateEmbed(ctx, euq); err != nil {
+			return err
+		}
+	}
+
+	if applyURL {
+		if err := r.UpdateEmbedApplyURL(ctx, s.current, s.def.Overrides.ApplyURL); err != nil {
+			return err
+		}
+	}
+
+	if len(immutable) > 0 {
+		return fmt.Errorf("%w: %s", ErrImmutableChange, strings.Join(immutable, ", "))
+	}
+
+	return nil
+}
+
+func diff(e *capis.Embed, d *Definition) []Change {
+	var out []Change
+
+	if e.Source.GroupID != d.Group {
+		out = append(out, Change{Field: "group_id", From: quote(e.Source.GroupID), To: quote(d.Group), Immutable: true})
+	}
+	if !equalStrings(e.Filters, d.Filters) {
+		out = append(out, Change{Field: "filters", From: list(e.Filters), To: list(d.Filters)})
+	}
+	if !equalStrings(e.Columns, d.Columns) {
+		out = append(out, Change{Field: "columns", From: list(e.Columns), To: list(d.Columns)})
+	}
+
+	out = append(out, diffTheme(e.Theme, d.Theme)...)
+
+	if e.Overrides.ApplyURL != d.Overrides.ApplyURL {
+		out = append(out, Change{Field: "overrides.apply_url", From: quote(e.Overrides.ApplyURL), To: quote(d.Overrides.ApplyURL)})
+	}
+	if e.Overrides.ButtonText != d.Overrides.ButtonText {
+		out = append(out, Change{Field: "overrides.button_text", From: quote(e.Overrides.ButtonText), To: quote(d.Overrides.ButtonText), Immutable: true})
+	}
+	if !equalMeta(e.Overrides.Meta, d.Overrides.Meta) {
+		out = append(out, Change{Field: "overrides.metadata", From: jsonString(e.Overrides.Meta), To: jsonString(d.Overrides.Meta), Immutable: true})
+	}
+
+	return out
+}
+
+// diffTheme compares each field of the themes by its JSON name.
+func diffTheme(from, to capis.EmbedTheme) []Change {
+	var out []Change
+
+	fv, tv := reflect.ValueOf(from), reflect.ValueOf(to)
+	for i := 0; i < fv.NumField(); i++ {
+		a, b := fv.Field(i).String(), tv.Field(i).String()
+		if a == b {
+			continue
+		}
+
+		name, _, _ := strings.Cut(fv.Type().Field(i).Tag.Get("json"), ",")
+		out = append(out, Change{Field: "theme." + name, From: quote(a), To: quote(b)})
+	}
+
+	return out
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalMeta(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return jsonString(a) == jsonString(b)
+}
+
+func quote(s string) string {
+	return fmt.Sprintf("%q", s)
+}
+
+func list(s []string) string {
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func jsonString(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,96 @@
+package embedconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	capis "lwebco.de/go-capis"
+)
+
+type stubRemote struct {
+	embeds   []*capis.Embed
+	created  []string
+	updated  []*capis.EmbedUpdateRequest
+	applyURL map[string]string
+}
+
+func (r *stubRemote) ListEmbeds(_ context.Context, offset, limit int64, _ *capis.EmbedFilters) (*capis.ListEmbedsResponse, error) {
+	return &capis.ListEmbedsResponse{Data: r.embeds}, nil
+}
+
+func (r *stubRemote) CreateEmbed(_ context.Context, e *capis.CreateEmbedRequest) error {
+	r.created = append(r.created, e.ID)
+	return nil
+}
+
+func (r *stubRemote) UpdateEmbed(_ context.Context, euq *capis.EmbedUpdateRequest) error {
+	r.updated = append(r.updated, euq)
+	return nil
+}
+
+func (r *stubRemote) UpdateEmbedApplyURL(_ context.Context, e *capis.Embed, u string) error {
+	r.applyURL[e.ID] = u
+	return nil
+}
+
+const definitions = `
+embeds:
+  - id: existing
+    group_id: mortgages
+    filters: [rate]
+    columns: [name, rate]
+    theme:
+      productBorder: "#000"
+    overrides:
+      apply_url: https://example.com/apply
+  - id: unchanged
+    group_id: loans
+  - id: missing
+    group_id: loans
+`
+
+func TestPlan(t *testing.T) {
+	cfg, err := Parse([]byte(definitions), FormatYAML)
+	assert.NoError(t, err)
+
+	r := &stubRemote{
+		applyURL: map[string]string{},
+		embeds: []*capis.Embed{
+			{
+				ID:      "existing",
+				Filters: []string{"rate"},
+				Columns: []string{"name"},
+				Theme:   capis.EmbedTheme{ProductBorder: "#fff"},
+				Overrides: capis.EmbedOverrides{
+					ButtonText: "Apply now",
+				},
+				Source: capis.EmbedProductSelector{GroupID: "mortgages"},
+			},
+			{ID: "unchanged", Source: capis.EmbedProductSelector{GroupID: "loans"}},
+		},
+	}
+
+	p, err := NewPlan(context.Background(), r, cfg)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ActionUpdate, p.Steps[0].Action)
+	assert.Equal(t, ActionNone, p.Steps[1].Action)
+	assert.Equal(t, ActionCreate, p.Steps[2].Action)
+	assert.Equal(t, `~ update existing
+    columns: [name] => [name, rate]
+    theme.productBorder: "#fff" => "#000"
+    overrides.apply_url: "" => "https://example.com/apply"
+    overrides.button_text: "Apply now" => "" (requires recreating the embed)
++ create missing
+`, p.String())
+
+	err = p.Apply(context.Background(), r)
+	assert.ErrorIs(t, err, ErrImmutableChange)
+	assert.Equal(t, []string{"missing"}, r.created)
+	if assert.Len(t, r.updated, 1) {
+		assert.Equal(t, "#000", r.updated[0].Theme.ProductBorder)
+	}
+	assert.Equal(t, "https://example.com/apply", r.applyURL["existing"])
+}
@@ -7,7 +7,6 @@ import (
 	"strconv"
 
 	querystring "github.com/google/go-querystring/query"
-	"go.opencensus.io/trace"
 )
 
 type (
@@ -82,9 +81,9 @@ type (
 
 	// EmbedOverrides ...
 	EmbedOverrides struct {
-		ButtonText string                 `json:"button_text"`
-		ApplyURL   string                 `json:"apply_url"`
-		Meta       map[string]interface{} `json:"metadata"`
+		ButtonText string   `json:"button_text"`
+		ApplyURL   string   `json:"apply_url"`
+		Meta       Metadata `json:"metadata"`
 	}
 
 	// EmbedProductSelector ...
@@ -120,7 +119,7 @@ type (
 
 // ListEmbeds ...
 func (c *Client) ListEmbeds(ctx context.Context, offset, limit int64, filters *EmbedFilters) (*ListEmbedsResponse, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.ListEmbeds")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.ListEmbeds")
 	defer span.End()
 
 	obj := &ListEmbedsResponse{}
@@ -128,13 +127,13 @@ func (c *Client) ListEmbeds(ctx context.Context, offset, limit int64, filters *E
 	qs.Set("offset", strconv.FormatInt(offset, 10))
 	qs.Set("limit", strconv.FormatInt(limit, 10))
 
-	req, err := c.newRequest("GET", "/v1/embeds?"+qs.Encode(), nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/embeds?"+qs.Encode(), nil)
 	if err != nil {
 		c.logError(err)
 		return nil, err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -151,18 +150,19 @@ func (c *Client) ListEmbeds(ctx context.Context, offset, limit int64, filters *E
 
 // FindEmbed ...
 func (c *Client) FindEmbed(ctx context.Context, id string) (*Embed, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.FindEmbed")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.FindEmbed")
 	defer span.End()
+	span.SetAttribute(AttributeEmbedID, id)
 
 	obj := &Embed{}
 
-	req, err := c.newRequest("GET", "/v1/embeds/"+id, nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/embeds/{id}", nil, id)
 	if err != nil {
 		c.logError(err)
 		return nil, err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -179,18 +179,19 @@ func (c *Client) FindEmbed(ctx context.Context, id string) (*Embed, error) {
 
 // FindEmbedDetailed ...
 func (c *Client) FindEmbedDetailed(ctx context.Context, id string) (*DetailedEmbed, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.FindEmbedDetailed")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.FindEmbedDetailed")
 	defer span.End()
+	span.SetAttribute(AttributeEmbedID, id)
 
 	obj := &DetailedEmbed{}
 
-	req, err := c.newRequest("GET", "/v1/embeds/"+id+"/detailed", nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/embeds/{id}/detailed", nil, id)
 	if err != nil {
 		c.logError(err)
 		return nil, err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -220,8 +221,9 @@ func NewCreateEmbedRequestForGroup(id string, theme EmbedTheme, overrides EmbedO
 
 // CreateEmbed ...
 func (c *Client) CreateEmbed(ctx context.Context, embed *CreateEmbedRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.CreateEmbed")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.CreateEmbed")
 	defer span.End()
+	span.SetAttribute(AttributeEmbedID, embed.ID)
 
 	b, err := json.Marshal(embed)
 	if err != nil {
@@ -229,13 +231,13 @@ func (c *Client) CreateEmbed(ctx context.Context, embed *CreateEmbedRequest) err
 		return err
 	}
 
-	req, err := c.newRequest("POST", "/v1/embeds", bytes.NewReader(b))
+	req, err := c.newRequest(ctx, "POST", "/v1/embeds", bytes.NewReader(b))
 	if err != nil {
 		c.logError(err)
 		return err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return ErrUnreachable
@@ -279,8 +281,9 @@ func (e *EmbedUpdateRequest) SetTheme(in EmbedTheme) *EmbedUpdateRequest {
 
 // UpdateEmbed will send the request to update the embed.
 func (c *Client) UpdateEmbed(ctx context.Context, euq *EmbedUpdateRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.UpdateEmbed")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.UpdateEmbed")
 	defer span.End()
+	span.SetAttribute(AttributeEmbedID, euq.id)
 
 	b, err := json.Marshal(euq)
 	if err != nil {
@@ -288,13 +291,13 @@ func (c *Client) UpdateEmbed(ctx context.Context, euq *EmbedUpdateRequest) error
 		return err
 	}
 
-	req, err := c.newRequest("PUT", "/v1/embeds/"+euq.id, bytes.NewReader(b))
+	req, err := c.newRequest(ctx, "PUT", "/v1/embeds/{id}", bytes.NewReader(b), euq.id)
 	if err != nil {
 		c.logError(err)
 		return err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return ErrUnreachable
@@ -311,20 +314,21 @@ func (c *Client) UpdateEmbed(ctx context.Context, euq *EmbedUpdateRequest) error
 
 // UpdateEmbedApplyURL will send the request to update the embed.
 func (c *Client) UpdateEmbedApplyURL(ctx context.Context, emb *Embed, newApplyURL string) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.UpdateEmbedApplyURL")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.UpdateEmbedApplyURL")
 	defer span.End()
+	span.SetAttribute(AttributeEmbedID, emb.ID)
 
 	b, _ := json.Marshal(map[string]string{
 		"new_apply_url": newApplyURL,
 	})
 
-	req, err := c.newRequest("POST", "/v1/embeds/"+emb.ID+"/update_apply_url", bytes.NewReader(b))
+	req, err := c.newRequest(ctx, "POST", "/v1/embeds/{id}/update_apply_url", bytes.NewReader(b), emb.ID)
 	if err != nil {
 		c.logError(err)
 		return err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return ErrUnreachable
@@ -0,0 +1,126 @@
+package capis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EmbedProblem is the kind of problem found when validating an embed.
+type EmbedProblem string
+
+const (
+	// EmbedUnknownColumn the column is not offered for any product type.
+	EmbedUnknownColumn EmbedProblem = "unknown_column"
+	// EmbedUnknownFilter the filter is not offered for any product type.
+	EmbedUnknownFilter EmbedProblem = "unknown_filter"
+	// EmbedUnavailableGroup the group is not available to build embeds with.
+	EmbedUnavailableGroup EmbedProblem = "unavailable_group"
+	// EmbedUnknownProductType the product type has no build configuration.
+	EmbedUnknownProductType EmbedProblem = "unknown_product_type"
+	// EmbedProductTypeMismatch the column or filter belongs to another product type.
+	EmbedProductTypeMismatch EmbedProblem = "product_type_mismatch"
+)
+
+type (
+	// EmbedFieldError is a single problem with a field of an embed request.
+	EmbedFieldError struct {
+		Problem EmbedProblem
+		// Field is the JSON name of the field, e.g. columns.
+		Field string
+		Value string
+		// ProductType of the embed, empty when it is not known.
+		ProductType ProductType
+	}
+
+	// EmbedValidationError contains all of the problems of an embed request.
+	EmbedValidationError struct {
+		Errors []*EmbedFieldError
+	}
+)
+
+func (e *EmbedFieldError) Error() string {
+	switch e.Problem {
+	case EmbedUnavailableGroup:
+		return fmt.Sprintf("%s: group %q is not available", e.Field, e.Value)
+	case EmbedUnknownProductType:
+		return fmt.Sprintf("%s: product type %q has no build configuration", e.Field, e.Value)
+	case EmbedProductTypeMismatch:
+		return fmt.Sprintf("%s: %q is not available for %s products", e.Field, e.Value, e.ProductType)
+	default:
+		return fmt.Sprintf("%s: unknown value %q", e.Field, e.Value)
+	}
+}
+
+func (e *EmbedValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "invalid embed: " + strings.Join(msgs, "; ")
+}
+
+// ValidateCreateEmbed will check the group, columns and filters of the
+// request are offered by the build configuration, the product type of the
+// embed is the type the group is available for.
+func (r *BuildConfigurationResponse) ValidateCreateEmbed(req *CreateEmbedRequest) error {
+	cfg := r.ForGroup(req.Group)
+	if cfg == nil {
+		return &EmbedValidationError{Errors: []*EmbedFieldError{
+			{Problem: EmbedUnavailableGroup, Field: "group_id", Value: req.Group},
+		}}
+	}
+
+	return r.validateEmbed(cfg, req.Columns, req.Filters)
+}
+
+// ValidateEmbedUpdate will check the columns and filters of the request
+// are offered by the build configuration for the product type of the
+// embed, see DetailedEmbed for the product type of an existing embed.
+func (r *BuildConfigurationResponse) ValidateEmbedUpdate(t ProductType, req *EmbedUpdateRequest) error {
+	cfg := r.ForType(t)
+	if cfg == nil {
+		return &EmbedValidationError{Errors: []*EmbedFieldError{
+			{Problem: EmbedUnknownProductType, Field: "product_type", Value: string(t)},
+		}}
+	}
+
+	return r.validateEmbed(cfg, req.Columns, req.Filters)
+}
+
+func (r *BuildConfigurationResponse) validateEmbed(cfg *BuildConfiguration, columns, filters []string) error {
+	var errs []*EmbedFieldError
+
+	for _, v := range columns {
+		if cfg.Column(v) != nil {
+			continue
+		}
+
+		problem := EmbedUnknownColumn
+		for _, other := range r.Data {
+			if other.Column(v) != nil {
+				problem = EmbedProductTypeMismatch
+			}
+		}
+		errs = append(errs, &EmbedFieldError{Problem: problem, Field: "columns", Value: v, ProductType: cfg.Type})
+	}
+
+	for _, v := range filters {
+		if cfg.Filter(v) != nil {
+			continue
+		}
+
+		problem := EmbedUnknownFilter
+		for _, other := range r.Data {
+			if other.Filter(v) != nil {
+				problem = EmbedProductTypeMismatch
+			}
+		}
+		errs = append(errs, &EmbedFieldError{Problem: problem, Field: "filters", Value: v, ProductType: cfg.Type})
+	}
+
+	if len(errs) > 0 {
+		return &EmbedValidationError{Errors: errs}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package capis
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const buildConfiguration = `{"data":[
+	{"type":"mortgage","columns":[{"value":"rate"},{"value":"fee"}],"filters":[{"value":"ltv","multi":true}],"available_groups":["mortgages"]},
+	{"type":"loan","columns":[{"value":"apr"}],"filters":[{"value":"term"}],"available_groups":["loans"]}
+]}`
+
+func TestValidateCreateEmbed(t *testing.T) {
+	cfg := &BuildConfigurationResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(buildConfiguration), cfg))
+
+	assert.NoError(t, cfg.ValidateCreateEmbed(&CreateEmbedRequest{
+		Group:   "mortgages",
+		Columns: []string{"rate", "fee"},
+		Filters: []string{"ltv"},
+	}))
+
+	err := cfg.ValidateCreateEmbed(&CreateEmbedRequest{
+		Group:   "mortgages",
+		Columns: []string{"rate", "apr", "nope"},
+		Filters: []string{"term"},
+	})
+
+	var verr *EmbedValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, []*EmbedFieldError{
+			{Problem: EmbedProductTypeMismatch, Field: "columns", Value: "apr", ProductType: TypeMortgage},
+			{Problem: EmbedUnknownColumn, Field: "columns", Value: "nope", ProductType: TypeMortgage},
+			{Problem: EmbedProductTypeMismatch, Field: "filters", Value: "term", ProductType: TypeMortgage},
+		}, verr.Errors)
+	}
+
+	err = cfg.ValidateCreateEmbed(&CreateEmbedRequest{Group: "missing"})
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, EmbedUnavailableGroup, verr.Errors[0].Problem)
+	}
+}
+
+func TestValidateEmbedUpdate(t *testing.T) {
+	cfg := &BuildConfigurationResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(buildConfiguration), cfg))
+
+	emb := &Embed{ID: "e1"}
+	assert.NoError(t, cfg.ValidateEmbedUpdate(TypeLoan, emb.Update().SetColumns([]string{"apr"})))
+	assert.Error(t, cfg.ValidateEmbedUpdate(TypeBankAccount, emb.Update()))
+}
@@ -0,0 +1,175 @@
+package capis
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MortgageRateType is the kind of interest rate of a mortgage.
+type MortgageRateType string
+
+const (
+	// RateFixed the rate does not change.
+	RateFixed MortgageRateType = "fixed"
+	// RateTracker the rate follows the base rate.
+	RateTracker MortgageRateType = "tracker"
+	// RateDiscount the rate is a discount on the standard variable rate.
+	RateDiscount MortgageRateType = "discount"
+	// RateVariable the rate is set by the lender.
+	RateVariable MortgageRateType = "variable"
+)
+
+// InterestPaid is how often interest is paid on a bank account.
+type InterestPaid string
+
+const (
+	// InterestPaidMonthly interest is added to the balance each month.
+	InterestPaidMonthly InterestPaid = "monthly"
+	// InterestPaidAnnually interest is added to the balance each year.
+	InterestPaidAnnually InterestPaid = "annually"
+	// InterestPaidOnMaturity interest is added to the balance when the
+	// account matures.
+	InterestPaidOnMaturity InterestPaid = "on maturity"
+)
+
+// EnumMode is how values that are not one of the known constants are
+// handled. The mode applies to the products sent and received by the
+// client and to Client.DecodeProducts, decoding always keeps the value as
+// it was sent so it is sent back unchanged.
+type EnumMode int
+
+const (
+	// EnumLenient keeps unknown values.
+	EnumLenient EnumMode = iota
+	// EnumStrict rejects products with unknown values before they are sent
+	// and when they are received.
+	EnumStrict
+)
+
+// ErrUnknownEnum is returned in strict mode when a product has an unknown
+// value.
+var ErrUnknownEnum = errors.New("unknown enum value")
+
+var (
+	mortgageRateTypes = []MortgageRateType{RateFixed, RateTracker, RateDiscount, RateVariable}
+	interestPaids     = []InterestPaid{InterestPaidMonthly, InterestPaidAnnually, InterestPaidOnMaturity}
+)
+
+// WithEnumMode returns an option to pass to New(), the default is
+// EnumLenient.
+func WithEnumMode(m EnumMode) Option {
+	return func(c *Client) error {
+		c.enumMode = m
+		return nil
+	}
+}
+
+func (t MortgageRateType) String() string {
+	return string(t)
+}
+
+// IsValid reports if the rate type is one of the known constants ignoring
+// case.
+func (t MortgageRateType) IsValid() bool {
+	return t.known() != ""
+}
+
+// known returns the constant matching the rate type or "".
+func (t MortgageRateType) known() MortgageRateType {
+	for _, v := range mortgageRateTypes {
+		if strings.EqualFold(string(t), string(v)) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (p InterestPaid) String() string {
+	return string(p)
+}
+
+// IsValid reports if the frequency is one of the known constants ignoring
+// case, an underscore matches a space.
+func (p InterestPaid) IsValid() bool {
+	return p.known() != ""
+}
+
+// known returns the constant matching the frequency or "".
+func (p InterestPaid) known() InterestPaid {
+	s := strings.ReplaceAll(string(p), "_", " ")
+	for _, v := range interestPaids {
+		if strings.EqualFold(s, string(v)) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (v *validator) enum(field, value string, valid bool) {
+	if value != "" && !valid {
+		v.add(field, "unknown value %q", value)
+	}
+}
+
+// enumChecker is implemented by types containing enums.
+type enumChecker interface {
+	checkEnums(v *validator)
+}
+
+func (m *Mortgage) checkEnums(v *validator) {
+	v.enum("type", string(m.Type), m.Type.IsValid())
+	v.enum("offer_interest_rate_type", string(m.OfferInterestRateType), m.OfferInterestRateType.IsValid())
+	v.enum("standard_interest_rate_type", string(m.StandardInterestRateType), m.StandardInterestRateType.IsValid())
+}
+
+func (r *NewMortgageRequest) checkEnums(v *validator) {
+	v.enum("type", string(r.Type), r.Type.IsValid())
+	v.enum("offer_interest_rate_type", string(r.OfferInterestRateType), r.OfferInterestRateType.IsValid())
+	v.enum("standard_interest_rate_type", string(r.StandardInterestRateType), r.StandardInterestRateType.IsValid())
+}
+
+func (r *ListMortgagesResponse) checkEnums(v *validator) {
+	for _, m := range r.Data {
+		m.checkEnums(v)
+	}
+}
+
+func (b *BankAccount) checkEnums(v *validator) {
+	v.enum("interest_paid", string(b.InterestPaid), b.InterestPaid.IsValid())
+}
+
+func (r *NewBankAccountRequest) checkEnums(v *validator) {
+	v.enum("interest_paid", string(r.InterestPaid), r.InterestPaid.IsValid())
+}
+
+func (r *ListBankAccountsResponse) checkEnums(v *validator) {
+	for _, b := range r.Data {
+		b.checkEnums(v)
+	}
+}
+
+// checkEnums returns an error wrapping ErrUnknownEnum in strict mode when
+// obj contains unknown values.
+func (c *Client) checkEnums(obj interface{}) error {
+	ec, ok := obj.(enumChecker)
+	if !ok || c.enumMode != EnumStrict {
+		return nil
+	}
+
+	v := &validator{}
+	ec.checkEnums(v)
+	if err := v.err(); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownEnum, err)
+	}
+	return nil
+}
+
+// decode unmarshals the response into obj and checks its enums.
+func (c *Client) decode(res *http.Response, obj interface{}) error {
+	if err := unmarshalResponse(res, obj); err != nil {
+		return err
+	}
+	return c.checkEnums(obj)
+}
@@ -0,0 +1,93 @@
+package capis
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumUnmarshal(t *testing.T) {
+	var m Mortgage
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"Fixed","offer_interest_rate_type":"trakcer"}`), &m))
+	assert.Equal(t, MortgageRateType("Fixed"), m.Type, "the value is kept as it was sent")
+	assert.True(t, m.Type.IsValid())
+	assert.Equal(t, MortgageRateType("trakcer"), m.OfferInterestRateType)
+	assert.False(t, m.OfferInterestRateType.IsValid())
+
+	var b BankAccount
+	require.NoError(t, json.Unmarshal([]byte(`{"interest_paid":"On Maturity"}`), &b))
+	assert.Equal(t, InterestPaid("On Maturity"), b.InterestPaid)
+	assert.True(t, b.InterestPaid.IsValid())
+	assert.True(t, InterestPaid("on_maturity").IsValid())
+
+	out, err := json.Marshal(&b)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"interest_paid":"On Maturity"`)
+}
+
+func TestEnumRoundTrip(t *testing.T) {
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"id":"b1","interest_paid":"On Maturity"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		sent = string(body)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")), WithEnumMode(EnumStrict))
+	require.NoError(t, err)
+
+	b, err := c.Products().FindBankAccount(context.Background(), "b1")
+	require.NoError(t, err)
+	require.NoError(t, c.Products().UpdateBankAccount(context.Background(), b))
+	assert.Contains(t, sent, `"interest_paid":"On Maturity"`)
+}
+
+func TestEnumMode(t *testing.T) {
+	called := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		_, _ = w.Write([]byte(`{"id":"m1","type":"fixd"}`))
+	}))
+	defer srv.Close()
+
+	lenient, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	m, err := lenient.Products().FindMortgage(context.Background(), "m1")
+	require.NoError(t, err)
+	assert.Equal(t, MortgageRateType("fixd"), m.Type)
+
+	strict, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")), WithEnumMode(EnumStrict))
+	require.NoError(t, err)
+
+	_, err = strict.Products().FindMortgage(context.Background(), "m1")
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+
+	err = strict.Products().UpdateMortgage(context.Background(), m)
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+	assert.Equal(t, 2, called)
+}
+
+func TestClientDecodeProducts(t *testing.T) {
+	b := []byte(`[{"id":"m1","loan_to_value":{"value":75},"type":"fixd"}]`)
+
+	products, err := DecodeProducts(b)
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+
+	strict, err := New(WithEnumMode(EnumStrict))
+	require.NoError(t, err)
+
+	_, err = strict.DecodeProducts(b)
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+}
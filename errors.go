@@ -20,6 +20,11 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrUnreachable is returned when we cannot connect to the capis server.
 	ErrUnreachable = errors.New("unreachable")
+	// ErrConflict is returned when the resource already exists.
+	ErrConflict = errors.New("conflict")
+	// ErrTooManyRequests is returned when the request is still throttled
+	// after it has been retried.
+	ErrTooManyRequests = errors.New("too many requests")
 )
 
 func statusCodeToError(sc int) error {
@@ -32,6 +37,10 @@ func statusCodeToError(sc int) error {
 		return ErrUnauthorized
 	case http.StatusNotFound:
 		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
 	default:
 		return &ErrUnknown{sc}
 	}
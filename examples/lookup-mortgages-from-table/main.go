@@ -5,11 +5,11 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"math"
 	"sync"
 	"time"
 
 	"lwebco.de/go-capis"
+	"lwebco.de/go-capis/eligibility"
 )
 
 var (
@@ -106,27 +106,25 @@ func repositoryFromClient(c *capis.Client, groupName string) *mortgageProductsRe
 
 type sourcingRun struct {
 	// request loan amount
-	loanAmount int
+	loanAmount capis.Money
 
-	// maximum cost of loan in whole pounds
-	maxCost int
+	// maximum cost of loan fees
+	maxCost capis.Money
 }
 
-func getFeeCost(loanAmount int, fee capis.Fee) int {
-	if fee.Fixed != nil {
-		return int(fee.Fixed.Amount)
+func (f sourcingRun) criteria() *eligibility.MortgageCriteria {
+	c := &eligibility.MortgageCriteria{
+		LoanAmount: f.loanAmount,
+		Borrower:   eligibility.BorrowerConsumer,
 	}
-
-	costF := (float64(loanAmount) / 100) * fee.Variable
-	return int(math.Ceil(costF))
+	if !f.maxCost.IsZero() {
+		c.MaxFee = &f.maxCost
+	}
+	return c
 }
 
 func (f sourcingRun) match(mortgage *capis.Mortgage) bool {
-	if f.maxCost > 0 && getFeeCost(f.loanAmount, mortgage.Fee) > f.maxCost {
-		return false
-	}
-
-	return true
+	return len(f.criteria().Check(mortgage)) == 0
 }
 
 func findMatching(repo *mortgageProductsRepository, matchFunc func(mortgage *capis.Mortgage) bool) (out []capis.Mortgage) {
@@ -158,8 +156,8 @@ func main() {
 	go repo.SyncEvery(ctx, tick)
 
 	params := sourcingRun{
-		loanAmount: 200000,
-		maxCost:    10000,
+		loanAmount: capis.NewMoney("GBP", 20000000, ""),
+		maxCost:    capis.NewMoney("GBP", 1000000, ""),
 	}
 
 	products := findMatching(repo, params.match)
@@ -169,17 +167,19 @@ func main() {
 		return
 	}
 
-	fmt.Printf("%d products where found:\n")
+	fmt.Printf("%d products where found:\n", len(products))
 	fmt.Println("===========")
 
 	for _, p := range products {
+		cost, _ := p.Fee.CostWith(params.loanAmount, capis.FeeCostOptions{Rounding: capis.RoundUp})
+
 		fmt.Printf(
-			"\t - [%s] %s (%.2f for %d months) + £%d in fees\n",
+			"\t - [%s] %s (%.2f for %d months) + %s in fees\n",
 			p.ID,
 			p.Name,
 			p.OfferInterestRate.Rate.Value,
 			p.OfferInterestRate.Period.Value,
-			getFeeCost(params.loanAmount, p.Fee),
+			cost,
 		)
 	}
 
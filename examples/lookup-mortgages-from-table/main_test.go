@@ -151,10 +151,12 @@ func TestSourcingRun(t *testing.T) {
 	}{
 		{
 			Params: sourcingRun{
-				loanAmount: 200000,
-				maxCost:    10000, // 5%
+				loanAmount: capis.NewMoney("GBP", 20000000, ""),
+				maxCost:    capis.NewMoney("GBP", 1000000, ""), // 5%
 			},
 			Mortgage: &capis.Mortgage{
+				Active:     true,
+				IsConsumer: true,
 				Fee: capis.Fee{
 					Variable:    6.0,
 					Description: "",
@@ -164,10 +166,12 @@ func TestSourcingRun(t *testing.T) {
 		},
 		{
 			Params: sourcingRun{
-				loanAmount: 200000,
-				maxCost:    10000, // 5%
+				loanAmount: capis.NewMoney("GBP", 20000000, ""),
+				maxCost:    capis.NewMoney("GBP", 1000000, ""), // 5%
 			},
 			Mortgage: &capis.Mortgage{
+				Active:     true,
+				IsConsumer: true,
 				Fee: capis.Fee{
 					Variable:    4.9,
 					Description: "",
@@ -177,13 +181,16 @@ func TestSourcingRun(t *testing.T) {
 		},
 		{
 			Params: sourcingRun{
-				loanAmount: 200000,
-				maxCost:    10000, // 5%
+				loanAmount: capis.NewMoney("GBP", 20000000, ""),
+				maxCost:    capis.NewMoney("GBP", 1000000, ""), // 5%
 			},
 			Mortgage: &capis.Mortgage{
+				Active:     true,
+				IsConsumer: true,
 				Fee: capis.Fee{
 					Fixed: &capis.Money{
-						Amount: 99999,
+						Currency: "GBP",
+						Amount:   9999900,
 					},
 					Description: "",
 				},
@@ -192,13 +199,16 @@ func TestSourcingRun(t *testing.T) {
 		},
 		{
 			Params: sourcingRun{
-				loanAmount: 200000,
-				maxCost:    10000, // 5%
+				loanAmount: capis.NewMoney("GBP", 20000000, ""),
+				maxCost:    capis.NewMoney("GBP", 1000000, ""), // 5%
 			},
 			Mortgage: &capis.Mortgage{
+				Active:     true,
+				IsConsumer: true,
 				Fee: capis.Fee{
 					Fixed: &capis.Money{
-						Amount: 9999,
+						Currency: "GBP",
+						Amount:   999900,
 					},
 					Description: "",
 				},
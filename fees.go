@@ -0,0 +1,158 @@
+package capis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode is how fractions of a minor unit are rounded.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero, e.g. 1.5p to 2p.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even minor unit.
+	RoundHalfEven
+	// RoundUp rounds any fraction away from zero.
+	RoundUp
+	// RoundDown truncates any fraction.
+	RoundDown
+)
+
+// FeeCostOptions customise how the cost of a fee is calculated.
+type FeeCostOptions struct {
+	Rounding RoundingMode
+	// Minimum is the least the fee will cost when set.
+	Minimum *Money
+	// Maximum is the most the fee will cost when set.
+	Maximum *Money
+}
+
+var (
+	// ErrInvalidCap is returned when the minimum cost is more than the
+	// maximum.
+	ErrInvalidCap = errors.New("minimum fee is more than the maximum")
+	// ErrInvalidPercent is returned when the variable fee is NaN or
+	// infinite.
+	ErrInvalidPercent = errors.New("variable fee is not a number")
+)
+
+// Cost returns the cost of the fee for the principal, the fixed amount is
+// added to the variable percentage of the principal rounded half up. A
+// fixed amount without a currency is taken to be in the principal currency.
+func (f Fee) Cost(principal Money) (Money, error) {
+	return f.CostWith(principal, FeeCostOptions{})
+}
+
+// CostWith returns the cost of the fee for the principal using the options.
+func (f Fee) CostWith(principal Money, opts FeeCostOptions) (Money, error) {
+	if !validPercent(f.Variable) {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidPercent, f.Variable)
+	}
+
+	cost := principal.Percent(f.Variable, opts.Rounding)
+	cost.Description = f.Description
+
+	if f.Fixed != nil {
+		fixed := *f.Fixed
+		if fixed.Currency == "" {
+			fixed.Currency = principal.Currency
+		}
+
+		var err error
+		if cost, err = cost.Add(fixed); err != nil {
+			return Money{}, fmt.Errorf("fixed fee %w", err)
+		}
+	}
+
+	if opts.Minimum != nil && opts.Maximum != nil {
+		if c, err := opts.Minimum.Cmp(*opts.Maximum); err != nil {
+			return Money{}, err
+		} else if c > 0 {
+			return Money{}, ErrInvalidCap
+		}
+	}
+
+	if opts.Minimum != nil {
+		c, err := cost.Cmp(*opts.Minimum)
+		if err != nil {
+			return Money{}, fmt.Errorf("minimum fee %w", err)
+		}
+		if c < 0 {
+			cost.Amount = opts.Minimum.Amount
+		}
+	}
+
+	if opts.Maximum != nil {
+		c, err := cost.Cmp(*opts.Maximum)
+		if err != nil {
+			return Money{}, fmt.Errorf("maximum fee %w", err)
+		}
+		if c > 0 {
+			cost.Amount = opts.Maximum.Amount
+		}
+	}
+
+	return cost, nil
+}
+
+// IsFree reports if the fee has neither a fixed nor variable cost.
+func (f Fee) IsFree() bool {
+	return (f.Fixed == nil || f.Fixed.Amount == 0) && f.Variable == 0
+}
+
+// Percent returns pcent percent of the money rounded to a minor unit, a
+// NaN or infinite pcent returns zero.
+func (m Money) Percent(pcent float64, mode RoundingMode) Money {
+	if !validPercent(pcent) {
+		return NewMoney(m.Currency, 0, "")
+	}
+
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(pcent, 'f', -1, 64))
+	if !ok {
+		r = new(big.Rat).SetFloat64(pcent)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt64(m.Amount))
+	r.Quo(r, big.NewRat(100, 1))
+
+	return NewMoney(m.Currency, roundRat(r, mode), "")
+}
+
+func validPercent(pcent float64) bool {
+	return !math.IsNaN(pcent) && !math.IsInf(pcent, 0)
+}
+
+// roundRat rounds the rational to an integer.
+func roundRat(r *big.Rat, mode RoundingMode) int64 {
+	num, den := new(big.Int).Set(r.Num()), r.Denom()
+	neg := num.Sign() < 0
+	num.Abs(num)
+
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		half := new(big.Int).Mul(rem, big.NewInt(2)).Cmp(den)
+
+		switch mode {
+		case RoundUp:
+			q.Add(q, big.NewInt(1))
+		case RoundHalfUp:
+			if half >= 0 {
+				q.Add(q, big.NewInt(1))
+			}
+		case RoundHalfEven:
+			if half > 0 || (half == 0 && q.Bit(0) == 1) {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+
+	if neg {
+		q.Neg(q)
+	}
+
+	return q.Int64()
+}
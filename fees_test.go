@@ -0,0 +1,69 @@
+package capis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeCost(t *testing.T) {
+	principal := NewMoney("GBP", 20000000, "")
+
+	cost, err := NewVariableFee(0.1, "").Cost(principal)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20000), cost.Amount)
+
+	cost, err = NewFixedFee(&Money{Currency: "GBP", Amount: 99900}, "arrangement").Cost(principal)
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney("GBP", 99900, "arrangement"), cost)
+
+	combined := Fee{Fixed: &Money{Amount: 50000}, Variable: 1}
+	cost, err = combined.Cost(principal)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(250000), cost.Amount)
+
+	_, err = NewFixedFee(&Money{Currency: "EUR", Amount: 1}, "").Cost(principal)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestFeeCostWith(t *testing.T) {
+	fee := NewVariableFee(1.5, "")
+	principal := NewMoney("GBP", 101, "")
+
+	for mode, expected := range map[RoundingMode]int64{
+		RoundHalfUp:   2,
+		RoundHalfEven: 2,
+		RoundUp:       2,
+		RoundDown:     1,
+	} {
+		cost, err := fee.CostWith(principal, FeeCostOptions{Rounding: mode})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cost.Amount, "rounding mode %d", mode)
+	}
+
+	halfEven, _ := NewVariableFee(50, "").CostWith(NewMoney("GBP", 5, ""), FeeCostOptions{Rounding: RoundHalfEven})
+	assert.Equal(t, int64(2), halfEven.Amount)
+
+	min, max := NewMoney("GBP", 50000, ""), NewMoney("GBP", 150000, "")
+	capped, err := NewVariableFee(1, "").CostWith(NewMoney("GBP", 100000000, ""), FeeCostOptions{Minimum: &min, Maximum: &max})
+	assert.NoError(t, err)
+	assert.Equal(t, max.Amount, capped.Amount)
+
+	floored, err := NewVariableFee(1, "").CostWith(NewMoney("GBP", 1000, ""), FeeCostOptions{Minimum: &min, Maximum: &max})
+	assert.NoError(t, err)
+	assert.Equal(t, min.Amount, floored.Amount)
+
+	_, err = fee.CostWith(principal, FeeCostOptions{Minimum: &max, Maximum: &min})
+	assert.ErrorIs(t, err, ErrInvalidCap)
+}
+
+func TestFeeCostInvalidPercent(t *testing.T) {
+	principal := NewMoney("GBP", 20000000, "")
+
+	for _, pcent := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := NewVariableFee(pcent, "").Cost(principal)
+		assert.ErrorIs(t, err, ErrInvalidPercent)
+		assert.Equal(t, int64(0), principal.Percent(pcent, RoundHalfUp).Amount)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 
 	querystring "github.com/google/go-querystring/query"
-	"go.opencensus.io/trace"
 )
 
 type (
@@ -22,35 +21,41 @@ type (
 
 	// GroupFilters ...
 	GroupFilters struct {
-		Type string `json:"type"`
+		Type     ProductType       `json:"type" url:"type,omitempty"`
+		Metadata MetadataSelectors `json:"metadata" url:"-"`
 	}
 
 	// Group ...
 	Group struct {
-		ID   string `json:"id"`
-		Type string `json:"type"`
+		ID   string      `json:"id"`
+		Type ProductType `json:"type"`
+		Meta Metadata    `json:"metadata,omitempty"`
 	}
 
 	// DetailedGroup ...
 	DetailedGroup struct {
-		ID       string   `json:"id"`
-		Type     string   `json:"type"`
-		Products []string `json:"product_ids"`
+		ID       string      `json:"id"`
+		Type     ProductType `json:"type"`
+		Products []string    `json:"product_ids"`
+		Meta     Metadata    `json:"metadata,omitempty"`
 	}
 )
 
 // ListGroups ...
 func (c *Client) ListGroups(ctx context.Context, filters *GroupFilters) (*ListGroupsResponse, error) {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.ListGroups")
+	defer span.End()
+
 	obj := &ListGroupsResponse{}
 	qs, _ := querystring.Values(filters)
 
-	req, err := c.newRequest("GET", "/v1/groups?"+qs.Encode(), nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/groups?"+qs.Encode(), nil)
 	if err != nil {
 		c.logError(err)
 		return nil, err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -62,20 +67,30 @@ func (c *Client) ListGroups(ctx context.Context, filters *GroupFilters) (*ListGr
 		return nil, err
 	}
 
-	return obj, unmarshalResponse(res, obj)
+	if err := unmarshalResponse(res, obj); err != nil {
+		return obj, err
+	}
+	if filters != nil {
+		obj.Data = filterMetadata(obj.Data, filters.Metadata, func(g *Group) Metadata { return g.Meta })
+	}
+	return obj, nil
 }
 
 // FindGroup ...
 func (c *Client) FindGroup(ctx context.Context, name string) (*FindGroupResponse, error) {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.FindGroup")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, name)
+
 	obj := &FindGroupResponse{}
 
-	req, err := c.newRequest("GET", "/v1/groups/"+name, nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/groups/{id}", nil, name)
 	if err != nil {
 		c.logError(err)
 		return nil, err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -92,14 +107,15 @@ func (c *Client) FindGroup(ctx context.Context, name string) (*FindGroupResponse
 
 // NewGroupRequest ...
 type NewGroupRequest struct {
-	ID   string `json:"id"`
-	Type string `json:"type"`
+	ID   string      `json:"id"`
+	Type ProductType `json:"type"`
 }
 
 // NewGroup ...
 func (c *Client) NewGroup(ctx context.Context, opts *NewGroupRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.NewGroup")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.NewGroup")
 	defer span.End()
+	span.SetAttribute(AttributeGroupID, opts.ID)
 
 	b, err := json.Marshal(opts)
 	if err != nil {
@@ -107,13 +123,13 @@ func (c *Client) NewGroup(ctx context.Context, opts *NewGroupRequest) error {
 		return err
 	}
 
-	req, err := c.newRequest("POST", "/v1/groups", bytes.NewReader(b))
+	req, err := c.newRequest(ctx, "POST", "/v1/groups", bytes.NewReader(b))
 	if err != nil {
 		c.logError(err)
 		return err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return ErrUnreachable
@@ -136,26 +152,31 @@ type SetGroupProductsRequest struct {
 
 // SetGroupProducts ...
 func (c *Client) SetGroupProducts(ctx context.Context, opts *SetGroupProductsRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.SetGroupProducts")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.SetGroupProducts")
 	defer span.End()
+	span.SetAttribute(AttributeGroupID, opts.GroupID)
 
 	b, err := json.Marshal(opts)
 	if err != nil {
+		c.logError(err)
 		return err
 	}
 
-	req, err := c.newRequest("POST", "/v1/groups/"+opts.GroupID+"/products", bytes.NewReader(b))
+	req, err := c.newRequest(ctx, "POST", "/v1/groups/{id}/products", bytes.NewReader(b), opts.GroupID)
 	if err != nil {
+		c.logError(err)
 		return err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
+		c.logError(err)
 		return ErrUnreachable
 	}
 	defer res.Body.Close()
 
 	if err := statusCodeToError(res.StatusCode); err != nil {
+		c.logError(err)
 		return err
 	}
 
@@ -164,5 +185,5 @@ func (c *Client) SetGroupProducts(ctx context.Context, opts *SetGroupProductsReq
 
 // IsType ...
 func (g *Group) IsType(t ProductType) bool {
-	return ProductType(g.Type) == t
+	return g.Type == t
 }
@@ -0,0 +1,137 @@
+package capis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GroupProductProblem is why a product cannot be added to a group.
+type GroupProductProblem string
+
+const (
+	// GroupProductMissing the product does not exist.
+	GroupProductMissing GroupProductProblem = "missing"
+	// GroupProductWrongType the product is not the type of the group.
+	GroupProductWrongType GroupProductProblem = "wrong_type"
+	// GroupProductDuplicate the product is listed more than once.
+	GroupProductDuplicate GroupProductProblem = "duplicate"
+)
+
+// productTypes are the product types that can be found by ID.
+var productTypes = []ProductType{TypeLoan, TypeMortgage, TypeBankAccount}
+
+type (
+	// GroupProductError is a single product that cannot be added to a group.
+	GroupProductError struct {
+		ID      string
+		Problem GroupProductProblem
+		// Type of the product when it is the wrong type.
+		Type ProductType
+	}
+
+	// GroupProductsError contains all of the products that cannot be added
+	// to the group.
+	GroupProductsError struct {
+		GroupID   string
+		GroupType ProductType
+		Errors    []*GroupProductError
+	}
+)
+
+func (e *GroupProductError) Error() string {
+	switch e.Problem {
+	case GroupProductWrongType:
+		return fmt.Sprintf("%s is a %s", e.ID, e.Type)
+	case GroupProductDuplicate:
+		return fmt.Sprintf("%s is listed more than once", e.ID)
+	}
+	return fmt.Sprintf("%s does not exist", e.ID)
+}
+
+func (e *GroupProductsError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, pe := range e.Errors {
+		msgs[i] = pe.Error()
+	}
+	return fmt.Sprintf("invalid products for %s group %s: %s", e.GroupType, e.GroupID, strings.Join(msgs, "; "))
+}
+
+// SetGroupProductsChecked will check each product exists, is the type of
+// the group and is listed once before setting the products, a
+// *GroupProductsError lists the products that cannot be added.
+func (c *Client) SetGroupProductsChecked(ctx context.Context, opts *SetGroupProductsRequest) error {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.SetGroupProductsChecked")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, opts.GroupID)
+
+	grp, err := c.FindGroup(ctx, opts.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkGroupProducts(ctx, grp.Data, opts.Products); err != nil {
+		return err
+	}
+
+	return c.SetGroupProducts(ctx, opts)
+}
+
+func (c *Client) checkGroupProducts(ctx context.Context, grp *DetailedGroup, ids []string) error {
+	gerr := &GroupProductsError{GroupID: grp.ID, GroupType: grp.Type}
+
+	seen := make(map[string]int, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		seen[id]++
+		switch seen[id] {
+		case 1:
+			unique = append(unique, id)
+		case 2:
+			gerr.Errors = append(gerr.Errors, &GroupProductError{ID: id, Problem: GroupProductDuplicate})
+		}
+	}
+
+	found, err := c.findProductsByID(ctx, grp.Type, unique)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, id := range unique {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		if len(gerr.Errors) == 0 {
+			return nil
+		}
+		return gerr
+	}
+
+	actual := map[string]ProductType{}
+	for _, t := range productTypes {
+		if t == grp.Type {
+			continue
+		}
+
+		others, err := c.findProductsByID(ctx, t, missing)
+		if err != nil {
+			return err
+		}
+		for id := range others {
+			actual[id] = t
+		}
+	}
+
+	for _, id := range missing {
+		if t, ok := actual[id]; ok {
+			gerr.Errors = append(gerr.Errors, &GroupProductError{ID: id, Problem: GroupProductWrongType, Type: t})
+		} else {
+			gerr.Errors = append(gerr.Errors, &GroupProductError{ID: id, Problem: GroupProductMissing})
+		}
+	}
+
+	return gerr
+}
@@ -0,0 +1,95 @@
+package capis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGroupProductsChecked(t *testing.T) {
+	products := map[string][]string{
+		"/v2/mortgages":    {"m1", "m2"},
+		"/v1/loans":        {"l1"},
+		"/v1/bankaccounts": {},
+	}
+
+	sets := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/groups/g" {
+			_, _ = w.Write([]byte(`{"data":{"id":"g","type":"mortgage","product_ids":[]}}`))
+			return
+		}
+		if r.URL.Path == "/v1/groups/g/products" {
+			sets++
+			return
+		}
+
+		var data []string
+		for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+			for _, known := range products[r.URL.Path] {
+				if id == known {
+					data = append(data, fmt.Sprintf(`{"id":%q}`, id))
+				}
+			}
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = c.SetGroupProductsChecked(ctx, &SetGroupProductsRequest{GroupID: "g", Products: []string{"m1", "l1", "x1"}})
+
+	var gerr *GroupProductsError
+	require.ErrorAs(t, err, &gerr)
+	assert.Equal(t, TypeMortgage, gerr.GroupType)
+	assert.Equal(t, []*GroupProductError{
+		{ID: "l1", Problem: GroupProductWrongType, Type: TypeLoan},
+		{ID: "x1", Problem: GroupProductMissing},
+	}, gerr.Errors)
+	assert.Equal(t, 0, sets)
+
+	err = c.SetGroupProductsChecked(ctx, &SetGroupProductsRequest{GroupID: "g", Products: []string{"m1", "m2", "m1", "x1", "m1"}})
+	require.ErrorAs(t, err, &gerr)
+	assert.Equal(t, []*GroupProductError{
+		{ID: "m1", Problem: GroupProductDuplicate},
+		{ID: "x1", Problem: GroupProductMissing},
+	}, gerr.Errors)
+	assert.Contains(t, err.Error(), "m1 is listed more than once")
+
+	err = c.SetGroupProductsChecked(ctx, &SetGroupProductsRequest{GroupID: "g", Products: []string{"m1", "m1"}})
+	require.ErrorAs(t, err, &gerr)
+	assert.Equal(t, []*GroupProductError{{ID: "m1", Problem: GroupProductDuplicate}}, gerr.Errors)
+	assert.Equal(t, 0, sets)
+
+	require.NoError(t, c.SetGroupProductsChecked(ctx, &SetGroupProductsRequest{GroupID: "g", Products: []string{"m2", "m1"}}))
+	assert.Equal(t, 1, sets)
+}
+
+func TestListGroupsTypeQuery(t *testing.T) {
+	var query string
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `{"data":[]}`)
+	}))
+	defer hs.Close()
+
+	c, err := New(WithBase(hs.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	_, err = c.ListGroups(context.Background(), &GroupFilters{Type: TypeMortgage})
+	require.NoError(t, err)
+	assert.Equal(t, "type=mortgage", query)
+
+	_, err = c.ListGroups(context.Background(), &GroupFilters{})
+	require.NoError(t, err)
+	assert.Empty(t, query)
+}
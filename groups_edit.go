@@ -0,0 +1,207 @@
+package capis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultGroupEditAttempts is the number of times a group edit is
+// attempted when the group changes while it is being edited.
+const DefaultGroupEditAttempts = 3
+
+// groupEditBackoff is the wait before the second attempt of a group edit,
+// it doubles for each attempt after.
+var groupEditBackoff = 100 * time.Millisecond
+
+var (
+	// ErrGroupEditConflict is returned when the group kept changing while
+	// it was being edited.
+	ErrGroupEditConflict = errors.New("group changed while it was being edited")
+	// ErrGroupProductsMismatch is returned when reordering with products
+	// that are not the products of the group.
+	ErrGroupProductsMismatch = errors.New("products do not match the group")
+	// ErrProductNotInGroup is returned when moving a product that is not
+	// in the group.
+	ErrProductNotInGroup = errors.New("product is not in the group")
+)
+
+// WithGroupEditAttempts returns an option to pass to New(), group edits
+// are attempted up to n times.
+func WithGroupEditAttempts(n int) Option {
+	return func(c *Client) error {
+		c.groupEditAttempts = n
+		return nil
+	}
+}
+
+// AddGroupProducts will add the products to the end of the group, products
+// already in the group keep their position.
+func (c *Client) AddGroupProducts(ctx context.Context, groupID string, ids ...string) error {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.AddGroupProducts")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, groupID)
+
+	return c.editGroup(ctx, groupID, func(current []string) ([]string, error) {
+		want := append([]string(nil), current...)
+		for _, id := range ids {
+			if !containsID(want, id) {
+				want = append(want, id)
+			}
+		}
+		return want, nil
+	}, func(after []string) bool {
+		added, _ := diffIDs(after, ids)
+		return len(added) == 0
+	})
+}
+
+// RemoveGroupProducts will remove the products from the group, the rest
+// keep their order.
+func (c *Client) RemoveGroupProducts(ctx context.Context, groupID string, ids ...string) error {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.RemoveGroupProducts")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, groupID)
+
+	return c.editGroup(ctx, groupID, func(current []string) ([]string, error) {
+		return withoutIDs(current, ids), nil
+	}, func(after []string) bool {
+		return len(withoutIDs(after, ids)) == len(after)
+	})
+}
+
+// ReorderGroupProducts will set the order of the products in the group,
+// which is the order they are shown in tables. The ids must be the
+// products of the group.
+func (c *Client) ReorderGroupProducts(ctx context.Context, groupID string, ids []string) error {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.ReorderGroupProducts")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, groupID)
+
+	return c.editGroup(ctx, groupID, func(current []string) ([]string, error) {
+		added, removed := diffIDs(current, ids)
+		if len(added) > 0 || len(removed) > 0 || len(current) != len(ids) {
+			return nil, fmt.Errorf("%w: added %v removed %v", ErrGroupProductsMismatch, added, removed)
+		}
+		return ids, nil
+	}, func(after []string) bool {
+		return equalIDs(after, ids)
+	})
+}
+
+// MoveGroupProduct will move the product to the index of the group, an
+// index past the end moves it to the end.
+func (c *Client) MoveGroupProduct(ctx context.Context, groupID, id string, index int) error {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.MoveGroupProduct")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, groupID)
+	span.SetAttribute(AttributeProductID, id)
+
+	// at is the index clamped to the group read by the current attempt.
+	var at int
+	return c.editGroup(ctx, groupID, func(current []string) ([]string, error) {
+		rest := withoutIDs(current, []string{id})
+		if len(rest) == len(current) {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotInGroup, id)
+		}
+
+		at = index
+		if at < 0 {
+			at = 0
+		}
+		if at > len(rest) {
+			at = len(rest)
+		}
+
+		return append(append(append(make([]string, 0, len(current)), rest[:at]...), id), rest[at:]...), nil
+	}, func(after []string) bool {
+		return at < len(after) && after[at] == id
+	})
+}
+
+// editGroup will read the group, apply the edit and set its products.
+// The group is read again to verify the edit, when it was overwritten by
+// another writer or the API reports a conflict the edit is attempted again
+// after a backoff.
+//
+// The edit is best-effort: the API has no ETags or endpoints to add or
+// remove single products, so a write by another client between the set
+// and the read that verifies it can still be lost without an error.
+func (c *Client) editGroup(ctx context.Context, groupID string, edit func(current []string) ([]string, error), applied func(after []string) bool) error {
+	attempts := c.groupEditAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := groupEditBackoff
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			t := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			case <-t.C:
+			}
+			backoff *= 2
+		}
+
+		grp, err := c.FindGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		current := grp.Data.Products
+		want, err := edit(current)
+		if err != nil {
+			return err
+		}
+		if equalIDs(current, want) {
+			return nil
+		}
+
+		err = c.SetGroupProducts(ctx, &SetGroupProductsRequest{GroupID: groupID, Products: want})
+		switch {
+		case errors.Is(err, ErrConflict):
+			continue
+		case err != nil:
+			return err
+		}
+
+		after, err := c.FindGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if applied(after.Data.Products) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s after %d attempts", ErrGroupEditConflict, groupID, attempts)
+}
+
+// withoutIDs returns the ids in have that are not in remove.
+func withoutIDs(have, remove []string) []string {
+	drop := make(map[string]bool, len(remove))
+	for _, id := range remove {
+		drop[id] = true
+	}
+
+	out := make([]string, 0, len(have))
+	for _, id := range have {
+		if !drop[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package capis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// groupServer is a group API where another writer can overwrite the
+// products after the next set.
+type groupServer struct {
+	mu        sync.Mutex
+	products  []string
+	overwrite []string
+	conflicts int
+	sets      int
+}
+
+func (s *groupServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		fmt.Fprintf(w, `{"data":{"id":"g","type":"mortgage","product_ids":["%s"]}}`, strings.Join(s.products, `","`))
+	case http.MethodPost:
+		var req SetGroupProductsRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.sets++
+		if s.conflicts > 0 {
+			s.conflicts--
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.products = req.Products
+		if s.overwrite != nil {
+			s.products, s.overwrite = s.overwrite, nil
+		}
+	}
+}
+
+func TestGroupEdits(t *testing.T) {
+	srv := &groupServer{products: []string{"a", "b"}}
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+
+	c, err := New(WithBase(hs.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	srv.overwrite = []string{"a", "b", "x"}
+	require.NoError(t, c.AddGroupProducts(ctx, "g", "c", "c"))
+	assert.Equal(t, []string{"a", "b", "x", "c"}, srv.products)
+	assert.Equal(t, 2, srv.sets)
+
+	require.NoError(t, c.RemoveGroupProducts(ctx, "g", "b"))
+	assert.Equal(t, []string{"a", "x", "c"}, srv.products)
+
+	require.NoError(t, c.MoveGroupProduct(ctx, "g", "c", 0))
+	assert.Equal(t, []string{"c", "a", "x"}, srv.products)
+
+	require.NoError(t, c.ReorderGroupProducts(ctx, "g", []string{"x", "a", "c"}))
+	assert.Equal(t, []string{"x", "a", "c"}, srv.products)
+
+	assert.ErrorIs(t, c.ReorderGroupProducts(ctx, "g", []string{"x", "a"}), ErrGroupProductsMismatch)
+	assert.ErrorIs(t, c.MoveGroupProduct(ctx, "g", "z", 0), ErrProductNotInGroup)
+
+	sets := srv.sets
+	require.NoError(t, c.AddGroupProducts(ctx, "g", "a"))
+	assert.Equal(t, sets, srv.sets, "no-op edits are not sent")
+}
+
+func TestGroupEditConflict(t *testing.T) {
+	srv := &groupServer{products: []string{"a"}, conflicts: 1}
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+
+	c, err := New(WithBase(hs.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddGroupProducts(context.Background(), "g", "b"))
+	assert.Equal(t, []string{"a", "b"}, srv.products)
+	assert.Equal(t, 2, srv.sets, "the set is attempted again after a 409")
+
+	srv.conflicts = DefaultGroupEditAttempts
+	err = c.AddGroupProducts(context.Background(), "g", "c")
+	assert.ErrorIs(t, err, ErrGroupEditConflict)
+
+	srv.conflicts = 1
+	ctx, cancel := context.WithTimeout(context.Background(), groupEditBackoff/2)
+	defer cancel()
+	assert.ErrorIs(t, c.AddGroupProducts(ctx, "g", "c"), context.DeadlineExceeded, "the backoff stops when ctx is done")
+}
+
+func TestMoveGroupProductClampsEachAttempt(t *testing.T) {
+	srv := &groupServer{products: []string{"a", "b", "c"}, overwrite: []string{"a", "b", "c", "d", "e", "f"}}
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+
+	c, err := New(WithBase(hs.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	require.NoError(t, c.MoveGroupProduct(context.Background(), "g", "a", 4))
+	assert.Equal(t, []string{"b", "c", "d", "e", "a", "f"}, srv.products)
+	assert.Equal(t, 2, srv.sets)
+}
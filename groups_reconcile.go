@@ -0,0 +1,140 @@
+package capis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type (
+	// DesiredGroup is the state ReconcileGroup will bring a group to.
+	DesiredGroup struct {
+		ID       string      `json:"id"`
+		Type     ProductType `json:"type"`
+		Products []string    `json:"product_ids"`
+	}
+
+	// ReconcileOptions customise ReconcileGroup.
+	ReconcileOptions struct {
+		// DryRun will compute the changes without making them.
+		DryRun bool
+	}
+
+	// GroupReconcileResult describes the changes needed to reach the
+	// desired state and if they were applied.
+	GroupReconcileResult struct {
+		ID        string
+		Created   bool
+		Added     []string
+		Removed   []string
+		Reordered bool
+		Applied   bool
+	}
+)
+
+// ErrGroupTypeMismatch is returned when the group exists with a different type.
+var ErrGroupTypeMismatch = errors.New("group exists with a different type")
+
+// Changed reports if the group differs from the desired state.
+func (r *GroupReconcileResult) Changed() bool {
+	return r.Created || len(r.Added) > 0 || len(r.Removed) > 0 || r.Reordered
+}
+
+// ReconcileGroup will create the group when it is missing and set its
+// products to the desired ones, the group is left untouched when it is
+// already in the desired state.
+func (c *Client) ReconcileGroup(ctx context.Context, desired *DesiredGroup, opts ReconcileOptions) (*GroupReconcileResult, error) {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.ReconcileGroup")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, desired.ID)
+
+	res := &GroupReconcileResult{ID: desired.ID}
+
+	var current []string
+	existing, err := c.FindGroup(ctx, desired.ID)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		res.Created = true
+	case err != nil:
+		return nil, err
+	case existing.Data.Type != desired.Type:
+		return nil, fmt.Errorf("%w: %s is %s not %s", ErrGroupTypeMismatch, desired.ID, existing.Data.Type, desired.Type)
+	default:
+		current = existing.Data.Products
+	}
+
+	res.Added, res.Removed = diffIDs(current, desired.Products)
+	res.Reordered = len(res.Added) == 0 && len(res.Removed) == 0 && !equalIDs(current, desired.Products)
+
+	if opts.DryRun || !res.Changed() {
+		return res, nil
+	}
+
+	if res.Created {
+		if err := c.NewGroup(ctx, &NewGroupRequest{ID: desired.ID, Type: desired.Type}); err != nil {
+			return res, err
+		}
+	}
+
+	if !equalIDs(current, desired.Products) {
+		if err := c.SetGroupProducts(ctx, &SetGroupProductsRequest{GroupID: desired.ID, Products: desired.Products}); err != nil {
+			return res, err
+		}
+	}
+
+	res.Applied = true
+	return res, nil
+}
+
+// ReconcileGroups will reconcile each of the groups in turn, stopping at
+// the first error.
+func (c *Client) ReconcileGroups(ctx context.Context, desired []*DesiredGroup, opts ReconcileOptions) ([]*GroupReconcileResult, error) {
+	out := make([]*GroupReconcileResult, 0, len(desired))
+
+	for _, d := range desired {
+		res, err := c.ReconcileGroup(ctx, d, opts)
+		if err != nil {
+			return out, fmt.Errorf("unable to reconcile group %s %w", d.ID, err)
+		}
+		out = append(out, res)
+	}
+
+	return out, nil
+}
+
+// diffIDs returns the ids in want but not in have and the ids in have but
+// not in want.
+func diffIDs(have, want []string) (added, removed []string) {
+	haveSet := make(map[string]bool, len(have))
+	for _, id := range have {
+		haveSet[id] = true
+	}
+
+	wantSet := make(map[string]bool, len(want))
+	for _, id := range want {
+		wantSet[id] = true
+		if !haveSet[id] {
+			added = append(added, id)
+		}
+	}
+
+	for _, id := range have {
+		if !wantSet[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	return added, removed
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
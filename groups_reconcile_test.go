@@ -0,0 +1,57 @@
+package capis
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileGroup(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		sent = append(sent, r.Method+" "+r.URL.Path+" "+string(b))
+
+		switch r.URL.Path {
+		case "/v1/groups/existing":
+			w.Write([]byte(`{"data":{"id":"existing","type":"mortgage","product_ids":["a","b"]}}`))
+		case "/v1/groups/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	ctx := context.Background()
+
+	res, err := c.ReconcileGroup(ctx, &DesiredGroup{ID: "existing", Type: "mortgage", Products: []string{"b", "c"}}, ReconcileOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c"}, res.Added)
+	assert.Equal(t, []string{"a"}, res.Removed)
+	assert.False(t, res.Applied)
+	assert.Len(t, sent, 1, "dry run must only read the group")
+
+	sent = nil
+	res, err = c.ReconcileGroup(ctx, &DesiredGroup{ID: "existing", Type: "mortgage", Products: []string{"a", "b"}}, ReconcileOptions{})
+	assert.NoError(t, err)
+	assert.False(t, res.Changed())
+	assert.Len(t, sent, 1, "nothing to change")
+
+	sent = nil
+	res, err = c.ReconcileGroup(ctx, &DesiredGroup{ID: "missing", Type: "loan", Products: []string{"x"}}, ReconcileOptions{})
+	assert.NoError(t, err)
+	assert.True(t, res.Created)
+	assert.True(t, res.Applied)
+	assert.Equal(t, []string{
+		"GET /v1/groups/missing ",
+		`POST /v1/groups {"id":"missing","type":"loan"}`,
+		`POST /v1/groups/missing/products {"product_ids":["x"]}`,
+	}, sent)
+
+	_, err = c.ReconcileGroup(ctx, &DesiredGroup{ID: "existing", Type: "loan"}, ReconcileOptions{})
+	assert.ErrorIs(t, err, ErrGroupTypeMismatch)
+}
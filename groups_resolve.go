@@ -0,0 +1,165 @@
+package capis
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// MaxIDQueryLength is the most bytes of the escaped id query parameter of
+// a single list request, groups with more are fetched in batches. It leaves
+// room for the rest of the URL within the common 2048 byte limit.
+const MaxIDQueryLength = 1500
+
+// ResolvedGroup is a group and the products in it.
+type ResolvedGroup struct {
+	Group *DetailedGroup
+	// Products are in the order of the group.
+	Products []Product
+	// Unresolved are the IDs in the group that no longer resolve to a
+	// product.
+	Unresolved []string
+}
+
+// Mortgages returns the products of a mortgage group.
+func (g *ResolvedGroup) Mortgages() []*Mortgage {
+	out := make([]*Mortgage, 0, len(g.Products))
+	for _, p := range g.Products {
+		if m, ok := p.(*Mortgage); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Loans returns the products of a loan group.
+func (g *ResolvedGroup) Loans() []*Loan {
+	out := make([]*Loan, 0, len(g.Products))
+	for _, p := range g.Products {
+		if l, ok := p.(*Loan); ok {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// BankAccounts returns the products of a bank account group.
+func (g *ResolvedGroup) BankAccounts() []*BankAccount {
+	out := make([]*BankAccount, 0, len(g.Products))
+	for _, p := range g.Products {
+		if b, ok := p.(*BankAccount); ok {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// ResolveGroup will return the group and fetch its products using the
+// list call of the group type.
+func (c *Client) ResolveGroup(ctx context.Context, name string) (*ResolvedGroup, error) {
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.ResolveGroup")
+	defer span.End()
+	span.SetAttribute(AttributeGroupID, name)
+
+	grp, err := c.FindGroup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := c.findProductsByID(ctx, grp.Data.Type, grp.Data.Products)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve group %s %w", name, err)
+	}
+
+	res := &ResolvedGroup{Group: grp.Data, Products: make([]Product, 0, len(grp.Data.Products))}
+	for _, id := range grp.Data.Products {
+		if p, ok := found[id]; ok {
+			res.Products = append(res.Products, p)
+		} else {
+			res.Unresolved = append(res.Unresolved, id)
+		}
+	}
+
+	return res, nil
+}
+
+// findProductsByID returns the products of the type with the ids in
+// batches, keyed by their ID.
+func (c *Client) findProductsByID(ctx context.Context, t ProductType, ids []string) (map[string]Product, error) {
+	found := make(map[string]Product, len(ids))
+	for _, batch := range batchIDs(ids, MaxIDQueryLength) {
+		products, err := c.listProductsByID(ctx, t, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range products {
+			found[p.GetID()] = p
+		}
+	}
+	return found, nil
+}
+
+func (c *Client) listProductsByID(ctx context.Context, t ProductType, ids []string) ([]Product, error) {
+	s := c.Products()
+	out := make([]Product, 0, len(ids))
+
+	switch t {
+	case TypeMortgage:
+		res, err := s.ListMortgages(ctx, &MortgageProductFilters{ID: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range res.Data {
+			out = append(out, m)
+		}
+	case TypeLoan:
+		res, err := s.ListLoans(ctx, &ProductFilters{ID: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range res.Data {
+			out = append(out, l)
+		}
+	case TypeBankAccount:
+		res, err := s.ListBankAccounts(ctx, &ProductFilters{ID: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range res.Data {
+			out = append(out, b)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProductType, t)
+	}
+
+	return out, nil
+}
+
+// batchIDs splits the ids into batches whose escaped id query parameter
+// is at most max bytes, an id longer than max is sent on its own.
+func batchIDs(ids []string, max int) [][]string {
+	var (
+		out   [][]string
+		batch []string
+		size  = len("id=")
+		sep   = len(url.QueryEscape(","))
+	)
+
+	for _, id := range ids {
+		n := len(url.QueryEscape(id))
+		if len(batch) > 0 && size+sep+n > max {
+			out = append(out, batch)
+			batch, size = nil, len("id=")
+		}
+		if len(batch) > 0 {
+			size += sep
+		}
+		batch = append(batch, id)
+		size += n
+	}
+
+	if len(batch) > 0 {
+		out = append(out, batch)
+	}
+	return out
+}
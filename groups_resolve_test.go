@@ -0,0 +1,68 @@
+package capis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	querystring "github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveGroup(t *testing.T) {
+	ids := make([]string, 120)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("e2db12d8-af0d-4bd8-8df2-%012d", i)
+	}
+
+	lists := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/groups/testing":
+			fmt.Fprintf(w, `{"data":{"id":"testing","type":"mortgage","product_ids":["%s"]}}`, strings.Join(ids, `","`))
+		case "/v2/mortgages":
+			lists++
+			var data []string
+			for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+				if id != ids[7] {
+					data = append(data, fmt.Sprintf(`{"id":%q}`, id))
+				}
+			}
+			fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	res, err := c.ResolveGroup(context.Background(), "testing")
+	require.NoError(t, err)
+
+	assert.Greater(t, lists, 1)
+	assert.Equal(t, []string{ids[7]}, res.Unresolved)
+	assert.Len(t, res.Mortgages(), 119)
+	assert.Equal(t, ids[8], res.Products[7].GetID())
+}
+
+func TestBatchIDs(t *testing.T) {
+	assert.Equal(t, [][]string{{"aa", "bb"}, {"cc"}}, batchIDs([]string{"aa", "bb", "cc"}, 10))
+	assert.Equal(t, [][]string{{"aa"}, {"bb"}, {"cc"}}, batchIDs([]string{"aa", "bb", "cc"}, 9))
+	assert.Nil(t, batchIDs(nil, 10))
+
+	ids := make([]string, 500)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("product %d/ü", i)
+	}
+	for _, batch := range batchIDs(ids, MaxIDQueryLength) {
+		v, err := querystring.Values(&ProductFilters{ID: batch})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(v.Encode()), MaxIDQueryLength)
+	}
+}
@@ -0,0 +1,30 @@
+package capis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingAuth struct{}
+
+func (failingAuth) AuthorizeRequest(ctx context.Context, req *http.Request) error {
+	return errors.New("no token")
+}
+
+func TestHealthy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c, _ := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	assert.True(t, c.Healthy(context.Background()))
+
+	var logged error
+	c, _ = New(WithBase(srv.URL), WithAuthProvider(failingAuth{}), WithErrorLog(func(err error) { logged = err }))
+	assert.False(t, c.Healthy(context.Background()))
+	assert.Error(t, logged)
+}
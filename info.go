@@ -2,47 +2,64 @@ package capis
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/pkg/errors"
-	"go.opencensus.io/trace"
 )
 
 type (
 	// BuildConfigurationResponse contains options used to build embeds.
 	BuildConfigurationResponse struct {
-		Data []struct {
-			Type    ProductType `json:"type"`
-			Columns []struct {
-				Value string `json:"value"`
-				Label string `json:"label"`
-			} `json:"columns"`
-			Filters []struct {
-				Value   string `json:"value"`
-				Label   string `json:"label"`
-				Mutli   bool   `json:"multi"`
-				Choices []struct {
-					Value string `json:"value"`
-					Label string `json:"label"`
-				} `json:"choices"`
-			} `json:"filters"`
-			AvailableGroups []string `json:"available_groups"`
-		} `json:"data"`
+		Data []*BuildConfiguration `json:"data"`
+	}
+
+	// BuildConfiguration contains the options to build embeds for a
+	// product type.
+	BuildConfiguration struct {
+		Type            ProductType     `json:"type"`
+		Columns         []*ColumnOption `json:"columns"`
+		Filters         []*FilterOption `json:"filters"`
+		AvailableGroups []string        `json:"available_groups"`
+	}
+
+	// ColumnOption is a column that can be shown in an embed.
+	ColumnOption struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	}
+
+	// FilterOption is a filter that can be offered in an embed.
+	FilterOption struct {
+		Value   string          `json:"value"`
+		Label   string          `json:"label"`
+		Multi   bool            `json:"multi"`
+		Choices []*FilterChoice `json:"choices"`
+
+		// Deprecated: use Multi. Mutli is set to Multi when decoding and
+		// multi is encoded as true when either is set.
+		Mutli bool `json:"-"`
+	}
+
+	// FilterChoice is a value that can be chosen for a filter.
+	FilterChoice struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
 	}
 )
 
 // GetBuildConfiguration will query capis for the available options to
 // build embeds with.
 func (c *Client) GetBuildConfiguration(ctx context.Context) (*BuildConfigurationResponse, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.GetBuildConfigurations")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.GetBuildConfigurations")
 	defer span.End()
 
-	req, err := c.newRequest("GET", "/v1/info/build-configurations", nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/info/build-configurations", nil)
 	if err != nil {
 		c.logError(err)
 		return nil, errors.Wrap(err, "unable to create request")
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -57,3 +74,82 @@ func (c *Client) GetBuildConfiguration(ctx context.Context) (*BuildConfiguration
 	var obj *BuildConfigurationResponse
 	return obj, unmarshalResponse(res, &obj)
 }
+
+// ForType returns the build configuration of the product type or nil.
+func (r *BuildConfigurationResponse) ForType(t ProductType) *BuildConfiguration {
+	for _, d := range r.Data {
+		if d.Type == t {
+			return d
+		}
+	}
+	return nil
+}
+
+// ForGroup returns the build configuration the group is available in or nil.
+func (r *BuildConfigurationResponse) ForGroup(group string) *BuildConfiguration {
+	for _, d := range r.Data {
+		if d.HasGroup(group) {
+			return d
+		}
+	}
+	return nil
+}
+
+// Column returns the column option with the value or nil.
+func (c *BuildConfiguration) Column(value string) *ColumnOption {
+	for _, col := range c.Columns {
+		if col.Value == value {
+			return col
+		}
+	}
+	return nil
+}
+
+// Filter returns the filter option with the value or nil.
+func (c *BuildConfiguration) Filter(value string) *FilterOption {
+	for _, f := range c.Filters {
+		if f.Value == value {
+			return f
+		}
+	}
+	return nil
+}
+
+// HasGroup reports if embeds can be built from the group.
+func (c *BuildConfiguration) HasGroup(group string) bool {
+	for _, g := range c.AvailableGroups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Choice returns the choice of the filter with the value or nil.
+func (f *FilterOption) Choice(value string) *FilterChoice {
+	for _, ch := range f.Choices {
+		if ch.Value == value {
+			return ch
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the filter option and sets the deprecated Mutli
+// field to Multi.
+func (o *FilterOption) UnmarshalJSON(b []byte) error {
+	type filterOption FilterOption
+	if err := json.Unmarshal(b, (*filterOption)(o)); err != nil {
+		return err
+	}
+	o.Mutli = o.Multi
+	return nil
+}
+
+// MarshalJSON encodes the filter option with multi set when either Multi
+// or the deprecated Mutli field is true.
+func (o FilterOption) MarshalJSON() ([]byte, error) {
+	type filterOption FilterOption
+	o.Multi = o.Multi || o.Mutli
+	return json.Marshal(filterOption(o))
+}
@@ -0,0 +1,43 @@
+package capis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildConfigurationLookups(t *testing.T) {
+	cfg := &BuildConfigurationResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(buildConfiguration), cfg))
+
+	mortgage := cfg.ForType(TypeMortgage)
+	if assert.NotNil(t, mortgage) {
+		assert.True(t, mortgage.Filter("ltv").Multi)
+		assert.NotNil(t, mortgage.Column("fee"))
+		assert.Nil(t, mortgage.Column("apr"))
+	}
+	assert.Nil(t, cfg.ForType(TypeCreditCard))
+	assert.Equal(t, TypeLoan, cfg.ForGroup("loans").Type)
+}
+
+func TestFilterOptionDeprecatedMutli(t *testing.T) {
+	cfg := &BuildConfigurationResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(buildConfiguration), cfg))
+
+	f := cfg.ForType(TypeMortgage).Filters[0]
+	assert.True(t, f.Multi)
+	assert.True(t, f.Mutli, "the deprecated field mirrors Multi")
+
+	b, err := json.Marshal(f)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "Mutli")
+
+	b, err = json.Marshal(&FilterOption{Value: "ltv", Mutli: true})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"multi":true`, "setting the deprecated field is kept")
+
+	b, err = json.Marshal([]FilterOption{{Value: "ltv", Multi: true}})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"multi":true`)
+}
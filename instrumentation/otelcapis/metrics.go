@@ -0,0 +1,95 @@
+package otelcapis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	capis "lwebco.de/go-capis"
+)
+
+type metrics struct {
+	requests    metric.Int64Counter
+	errors      metric.Int64Counter
+	retries     metric.Int64Counter
+	authRefresh metric.Int64Counter
+	duration    metric.Float64Histogram
+}
+
+func newMetrics(m metric.Meter) (*metrics, error) {
+	var (
+		out = &metrics{}
+		err error
+	)
+
+	if out.requests, err = m.Int64Counter("capis.client.requests",
+		metric.WithDescription("Requests sent to comparisonapis.com."), metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+
+	if out.errors, err = m.Int64Counter("capis.client.errors",
+		metric.WithDescription("Requests that failed or returned an error status code."), metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+
+	if out.retries, err = m.Int64Counter("capis.client.retries",
+		metric.WithDescription("Requests retried by the client."), metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+
+	if out.authRefresh, err = m.Int64Counter("capis.client.auth.refreshes",
+		metric.WithDescription("Tokens fetched by the auth provider."), metric.WithUnit("{refresh}")); err != nil {
+		return nil, err
+	}
+
+	if out.duration, err = m.Float64Histogram("http.client.request.duration",
+		metric.WithDescription("Duration of the requests sent to comparisonapis.com."), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (m *metrics) RequestStarted(ctx context.Context, _ *capis.RequestEvent) context.Context {
+	return ctx
+}
+
+func (m *metrics) RequestFinished(ctx context.Context, ev *capis.RequestEvent) {
+	attrs := []attribute.KeyValue{
+		attribute.String(capis.AttributeHTTPMethod, ev.Request.Method),
+		attribute.String(capis.AttributeURLTemplate, ev.Route),
+	}
+
+	switch {
+	case ev.Err != nil:
+		attrs = append(attrs, attribute.String("error.type", fmt.Sprintf("%T", ev.Err)))
+	case ev.Response.StatusCode >= 400:
+		attrs = append(attrs,
+			attribute.Int(capis.AttributeHTTPStatusCode, ev.Response.StatusCode),
+			attribute.String("error.type", strconv.Itoa(ev.Response.StatusCode)))
+	default:
+		attrs = append(attrs, attribute.Int(capis.AttributeHTTPStatusCode, ev.Response.StatusCode))
+	}
+
+	set := metric.WithAttributes(attrs...)
+	m.requests.Add(ctx, 1, set)
+	m.duration.Record(ctx, ev.Elapsed.Seconds(), set)
+
+	if ev.Err != nil || ev.Response.StatusCode >= 400 {
+		m.errors.Add(ctx, 1, set)
+	}
+}
+
+func (m *metrics) RequestRetried(ctx context.Context, ev *capis.RequestEvent) {
+	m.retries.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(capis.AttributeHTTPMethod, ev.Request.Method),
+		attribute.String(capis.AttributeURLTemplate, ev.Route),
+	))
+}
+
+func (m *metrics) AuthRefreshed(ctx context.Context, err error) {
+	m.authRefresh.Add(ctx, 1, metric.WithAttributes(attribute.Bool("error", err != nil)))
+}
@@ -0,0 +1,128 @@
+// Package otelcapis instruments the capis client with OpenTelemetry.
+package otelcapis
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	capis "lwebco.de/go-capis"
+)
+
+const instrumentationName = "lwebco.de/go-capis/instrumentation/otelcapis"
+
+type (
+	// Option customises the instrumentation.
+	Option func(*config)
+
+	config struct {
+		tp          trace.TracerProvider
+		mp          metric.MeterProvider
+		propagators propagation.TextMapPropagator
+	}
+
+	tracer struct {
+		t trace.Tracer
+	}
+
+	span struct {
+		s trace.Span
+	}
+)
+
+// New returns an option to pass to capis.New(), it replaces the OpenCensus
+// tracer, records the client metrics and propagates the trace context on
+// outgoing requests. The global providers are used unless given as options.
+func New(opts ...Option) capis.Option {
+	cfg := &config{
+		tp:          otel.GetTracerProvider(),
+		mp:          otel.GetMeterProvider(),
+		propagators: otel.GetTextMapPropagator(),
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *capis.Client) error {
+		m, err := newMetrics(cfg.mp.Meter(instrumentationName))
+		if err != nil {
+			return err
+		}
+
+		for _, opt := range []capis.Option{
+			capis.WithTracer(&tracer{cfg.tp.Tracer(instrumentationName)}),
+			capis.WithObserver(m),
+			capis.WithRequestMiddleware(inject(cfg.propagators)),
+		} {
+			if err := opt(c); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithTracerProvider sets the tracer provider used to start spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tp = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider used to record metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.mp = mp
+	}
+}
+
+// WithPropagators sets the propagators used to inject the trace context
+// into the request headers.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+func inject(p propagation.TextMapPropagator) capis.RequestMiddlewareFunc {
+	return func(r *http.Request) *http.Request {
+		p.Inject(r.Context(), propagation.HeaderCarrier(r.Header))
+		return r
+	}
+}
+
+func (t *tracer) Start(ctx context.Context, name string) (context.Context, capis.Span) {
+	ctx, s := t.t.Start(ctx, strings.TrimPrefix(name, "lwebco.de/go-capis/"), trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &span{s}
+}
+
+func (s *span) SetAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.s.SetAttributes(attribute.String(key, v))
+	case int:
+		s.s.SetAttributes(attribute.Int(key, v))
+	case int64:
+		s.s.SetAttributes(attribute.Int64(key, v))
+	case bool:
+		s.s.SetAttributes(attribute.Bool(key, v))
+	}
+}
+
+func (s *span) SetError(err error) {
+	s.s.RecordError(err)
+	s.s.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.s.End()
+}
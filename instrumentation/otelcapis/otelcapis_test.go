@@ -0,0 +1,69 @@
+package otelcapis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	capis "lwebco.de/go-capis"
+)
+
+func TestNew(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	c, err := capis.New(
+		capis.WithBase(srv.URL),
+		capis.WithAuthProvider(capis.StaticToken("token")),
+		New(
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+			WithPropagators(propagation.TraceContext{}),
+		),
+	)
+	assert.NoError(t, err)
+
+	_, err = c.FindGroup(context.Background(), "testing")
+	assert.Equal(t, capis.ErrNotFound, err)
+
+	spans := sr.Ended()
+	if assert.Len(t, spans, 1) {
+		s := spans[0]
+		assert.Equal(t, "Client.FindGroup", s.Name())
+		assert.Equal(t, codes.Error, s.Status().Code)
+		assert.Contains(t, s.Attributes(), attribute.String(capis.AttributeGroupID, "testing"))
+		assert.Contains(t, s.Attributes(), attribute.Int(capis.AttributeHTTPStatusCode, 404))
+		assert.Contains(t, s.Attributes(), attribute.String(capis.AttributeURLTemplate, "/v1/groups/{id}"))
+		assert.Contains(t, traceparent, s.SpanContext().TraceID().String())
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+
+	names := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			names[m.Name] = true
+		}
+	}
+	assert.True(t, names["capis.client.requests"])
+	assert.True(t, names["capis.client.errors"])
+	assert.True(t, names["http.client.request.duration"])
+}
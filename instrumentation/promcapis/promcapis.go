@@ -0,0 +1,168 @@
+// Package promcapis instruments the capis client with Prometheus metrics.
+package promcapis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	capis "lwebco.de/go-capis"
+)
+
+type (
+	// Option customises the collector.
+	Option func(*config)
+
+	config struct {
+		namespace   string
+		buckets     []float64
+		constLabels prometheus.Labels
+	}
+
+	// Collector is a prometheus.Collector recording the requests of the
+	// clients it is passed to with capis.WithObserver().
+	Collector struct {
+		requests      *prometheus.CounterVec
+		duration      *prometheus.HistogramVec
+		inFlight      *prometheus.GaugeVec
+		retries       *prometheus.CounterVec
+		authRefreshes *prometheus.CounterVec
+	}
+)
+
+// New returns an option to pass to capis.New(), a new collector is
+// registered with reg for the client. Use NewCollector when several
+// clients should share the same metrics.
+func New(reg prometheus.Registerer, opts ...Option) capis.Option {
+	return func(c *capis.Client) error {
+		col := NewCollector(opts...)
+		if err := reg.Register(col); err != nil {
+			return err
+		}
+
+		return capis.WithObserver(col)(c)
+	}
+}
+
+// NewCollector returns a collector that is not registered.
+func NewCollector(opts ...Option) *Collector {
+	cfg := &config{
+		namespace: "capis",
+		buckets:   prometheus.DefBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Subsystem:   "client",
+			Name:        "requests_total",
+			Help:        "Requests sent to comparisonapis.com by method, route and status.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   cfg.namespace,
+			Subsystem:   "client",
+			Name:        "request_duration_seconds",
+			Help:        "Duration of the requests sent to comparisonapis.com.",
+			Buckets:     cfg.buckets,
+			ConstLabels: cfg.constLabels,
+		}, []string{"method", "route"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   cfg.namespace,
+			Subsystem:   "client",
+			Name:        "requests_in_flight",
+			Help:        "Requests currently waiting for a response.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"method", "route"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Subsystem:   "client",
+			Name:        "retries_total",
+			Help:        "Requests retried by the client.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"method", "route"}),
+		authRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Subsystem:   "client",
+			Name:        "auth_refreshes_total",
+			Help:        "Tokens fetched by the auth provider by result.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"result"}),
+	}
+}
+
+// WithNamespace sets the namespace of the metrics, defaults to capis.
+func WithNamespace(ns string) Option {
+	return func(c *config) {
+		c.namespace = ns
+	}
+}
+
+// WithBuckets sets the buckets of the request duration histogram.
+func WithBuckets(b []float64) Option {
+	return func(c *config) {
+		c.buckets = b
+	}
+}
+
+// WithConstLabels sets labels added to all of the metrics.
+func WithConstLabels(l prometheus.Labels) Option {
+	return func(c *config) {
+		c.constLabels = l
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.inFlight.Describe(ch)
+	c.retries.Describe(ch)
+	c.authRefreshes.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.inFlight.Collect(ch)
+	c.retries.Collect(ch)
+	c.authRefreshes.Collect(ch)
+}
+
+// RequestStarted implements capis.Observer.
+func (c *Collector) RequestStarted(ctx context.Context, ev *capis.RequestEvent) context.Context {
+	c.inFlight.WithLabelValues(ev.Request.Method, ev.Route).Inc()
+	return ctx
+}
+
+// RequestFinished implements capis.Observer.
+func (c *Collector) RequestFinished(_ context.Context, ev *capis.RequestEvent) {
+	c.inFlight.WithLabelValues(ev.Request.Method, ev.Route).Dec()
+	c.duration.WithLabelValues(ev.Request.Method, ev.Route).Observe(ev.Elapsed.Seconds())
+
+	status := "error"
+	if ev.Err == nil {
+		status = strconv.Itoa(ev.Response.StatusCode)
+	}
+	c.requests.WithLabelValues(ev.Request.Method, ev.Route, status).Inc()
+}
+
+// RequestRetried implements capis.Observer.
+func (c *Collector) RequestRetried(_ context.Context, ev *capis.RequestEvent) {
+	c.retries.WithLabelValues(ev.Request.Method, ev.Route).Inc()
+}
+
+// AuthRefreshed implements capis.Observer.
+func (c *Collector) AuthRefreshed(_ context.Context, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	c.authRefreshes.WithLabelValues(result).Inc()
+}
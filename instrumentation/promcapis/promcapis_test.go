@@ -0,0 +1,41 @@
+package promcapis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	capis "lwebco.de/go-capis"
+)
+
+func TestNew(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	reg := prometheus.NewPedanticRegistry()
+	c, err := capis.New(
+		capis.WithBase(srv.URL),
+		capis.WithAuthProvider(capis.StaticToken("token")),
+		New(reg),
+	)
+	assert.NoError(t, err)
+
+	_, err = c.Products().ListMortgages(context.Background(), &capis.MortgageProductFilters{ID: []string{"a", "b"}})
+	assert.NoError(t, err)
+
+	expected := `
+# HELP capis_client_requests_total Requests sent to comparisonapis.com by method, route and status.
+# TYPE capis_client_requests_total counter
+capis_client_requests_total{method="GET",route="/v2/mortgages",status="200"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "capis_client_requests_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "capis_client_request_duration_seconds"))
+}
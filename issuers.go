@@ -7,7 +7,6 @@ import (
 	"strconv"
 
 	querystring "github.com/google/go-querystring/query"
-	"go.opencensus.io/trace"
 )
 
 type (
@@ -44,7 +43,7 @@ type (
 
 // ListIssuers ...
 func (c *Client) ListIssuers(ctx context.Context, filters *IssuerFilters, start, limit int) (*ListIssuersResponse, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.ListIssuers")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.ListIssuers")
 	defer span.End()
 
 	qs, _ := querystring.Values(filters)
@@ -53,13 +52,13 @@ func (c *Client) ListIssuers(ctx context.Context, filters *IssuerFilters, start,
 
 	obj := &ListIssuersResponse{}
 
-	req, err := c.newRequest("GET", "/v1/issuers?"+qs.Encode(), nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/issuers?"+qs.Encode(), nil)
 	if err != nil {
 		c.logError(err)
 		return nil, err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -76,18 +75,19 @@ func (c *Client) ListIssuers(ctx context.Context, filters *IssuerFilters, start,
 
 // FindIssuer ...
 func (c *Client) FindIssuer(ctx context.Context, id string) (*Issuer, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.FindIssuer")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.FindIssuer")
 	defer span.End()
+	span.SetAttribute(AttributeIssuerID, id)
 
 	obj := &Issuer{}
 
-	req, err := c.newRequest("GET", "/v1/issuers/"+id, nil)
+	req, err := c.newRequest(ctx, "GET", "/v1/issuers/{id}", nil, id)
 	if err != nil {
 		c.logError(err)
 		return nil, err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return nil, ErrUnreachable
@@ -104,8 +104,9 @@ func (c *Client) FindIssuer(ctx context.Context, id string) (*Issuer, error) {
 
 // NewIssuer ...
 func (c *Client) NewIssuer(ctx context.Context, opts *NewIssuerRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/Client.NewIssuer")
+	ctx, span := c.startSpan(ctx, "lwebco.de/go-capis/Client.NewIssuer")
 	defer span.End()
+	span.SetAttribute(AttributeIssuerID, opts.ID)
 
 	b, err := json.Marshal(opts)
 	if err != nil {
@@ -113,13 +114,13 @@ func (c *Client) NewIssuer(ctx context.Context, opts *NewIssuerRequest) error {
 		return err
 	}
 
-	req, err := c.newRequest("POST", "/v1/issuers", bytes.NewReader(b))
+	req, err := c.newRequest(ctx, "POST", "/v1/issuers", bytes.NewReader(b))
 	if err != nil {
 		c.logError(err)
 		return err
 	}
 
-	res, err := c.Do(req.WithContext(ctx))
+	res, err := c.Do(req)
 	if err != nil {
 		c.logError(err)
 		return ErrUnreachable
@@ -0,0 +1,94 @@
+package capis
+
+import (
+	"context"
+	"log/slog"
+)
+
+type (
+	// LogLevels are the levels each client event is logged at.
+	LogLevels struct {
+		// Request is used when a request is about to be sent.
+		Request slog.Level
+		// Response is used when a request has completed successfully.
+		Response slog.Level
+		// Error is used for transport errors and error status codes.
+		Error slog.Level
+		// Retry is used when a request is about to be retried.
+		Retry slog.Level
+		// AuthRefresh is used when the auth provider fetches a new token.
+		AuthRefresh slog.Level
+	}
+
+	logObserver struct {
+		l      *slog.Logger
+		levels LogLevels
+	}
+)
+
+// DefaultLogLevels are used unless WithLogLevels is given to New().
+var DefaultLogLevels = LogLevels{
+	Request:     slog.LevelDebug,
+	Response:    slog.LevelInfo,
+	Error:       slog.LevelError,
+	Retry:       slog.LevelWarn,
+	AuthRefresh: slog.LevelInfo,
+}
+
+// WithLogger returns an option to pass to New(), requests are logged to
+// the handler with their method, route, status and duration. Headers and
+// bodies are never logged.
+func WithLogger(h slog.Handler) Option {
+	return func(c *Client) error {
+		c.logger = slog.New(h).With(slog.String("component", "capis"))
+		return nil
+	}
+}
+
+// WithLogLevels returns an option to pass to New()
+func WithLogLevels(levels LogLevels) Option {
+	return func(c *Client) error {
+		c.logLevels = levels
+		return nil
+	}
+}
+
+func (o *logObserver) RequestStarted(ctx context.Context, ev *RequestEvent) context.Context {
+	o.l.LogAttrs(ctx, o.levels.Request, "capis request started", requestAttrs(ev)...)
+	return ctx
+}
+
+func (o *logObserver) RequestFinished(ctx context.Context, ev *RequestEvent) {
+	attrs := append(requestAttrs(ev), slog.Duration("duration", ev.Elapsed))
+
+	switch {
+	case ev.Err != nil:
+		o.l.LogAttrs(ctx, o.levels.Error, "capis request failed", append(attrs, slog.String("error", ev.Err.Error()))...)
+	case ev.Response.StatusCode >= 400:
+		o.l.LogAttrs(ctx, o.levels.Error, "capis request failed", append(attrs, slog.Int("status", ev.Response.StatusCode))...)
+	default:
+		o.l.LogAttrs(ctx, o.levels.Response, "capis request finished", append(attrs, slog.Int("status", ev.Response.StatusCode))...)
+	}
+}
+
+func (o *logObserver) RequestRetried(ctx context.Context, ev *RequestEvent) {
+	o.l.LogAttrs(ctx, o.levels.Retry, "capis request retrying", append(requestAttrs(ev), slog.Duration("wait", ev.Wait))...)
+}
+
+func (o *logObserver) AuthRefreshed(ctx context.Context, err error) {
+	if err != nil {
+		o.l.LogAttrs(ctx, o.levels.Error, "capis auth refresh failed", slog.String("error", err.Error()))
+		return
+	}
+
+	o.l.LogAttrs(ctx, o.levels.AuthRefresh, "capis auth refreshed")
+}
+
+func requestAttrs(ev *RequestEvent) []slog.Attr {
+	return []slog.Attr{
+		slog.String("method", ev.Request.Method),
+		slog.String("route", ev.Route),
+		slog.String("url", ev.Request.URL.Redacted()),
+		slog.Int("attempt", ev.Attempt),
+	}
+}
@@ -0,0 +1,36 @@
+package capis
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	buf := &bytes.Buffer{}
+	c, _ := New(
+		WithBase(srv.URL),
+		WithAuthProvider(StaticToken("secret-token")),
+		WithLogger(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	)
+
+	_, err := c.FindGroup(context.Background(), "abc")
+	assert.Equal(t, ErrNotFound, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `"msg":"capis request started"`)
+	assert.Contains(t, out, `"route":"/v1/groups/{id}"`)
+	assert.Contains(t, out, `"status":404`)
+	assert.Contains(t, out, `"level":"ERROR"`)
+	assert.NotContains(t, out, "secret-token")
+}
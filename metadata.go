@@ -0,0 +1,150 @@
+package capis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Metadata is the metadata of products and embeds, the keys are usually
+// annotations such as AnnotationHighlightedProduct.
+type Metadata map[string]interface{}
+
+// annotationValidators check the values of the well-known annotations.
+var annotationValidators = map[string]func(v interface{}) string{
+	AnnotationHighlightedProduct: validBool,
+	AnnotationCampaign:           validString,
+	AnnotationPartner:            validString,
+	AnnotationValidFrom:          validTime,
+	AnnotationValidUntil:         validTime,
+}
+
+// Get returns the value of the key.
+func (m Metadata) Get(key string) (interface{}, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// GetString returns the value of the key when it is a string.
+func (m Metadata) GetString(key string) (string, bool) {
+	s, ok := m[key].(string)
+	return s, ok
+}
+
+// GetBool returns the value of the key when it is a bool or the string
+// "true" or "false".
+func (m Metadata) GetBool(key string) (bool, bool) {
+	switch v := m[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		switch v {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// GetTime returns the value of the key when it is an RFC 3339 timestamp.
+func (m Metadata) GetTime(key string) (time.Time, bool) {
+	s, ok := m[key].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// Set sets the value of the key, times are stored as RFC 3339 timestamps.
+func (m *Metadata) Set(key string, v interface{}) {
+	if *m == nil {
+		*m = Metadata{}
+	}
+
+	if t, ok := v.(time.Time); ok {
+		v = t.Format(time.RFC3339)
+	}
+	(*m)[key] = v
+}
+
+// Delete removes the key.
+func (m Metadata) Delete(key string) {
+	delete(m, key)
+}
+
+// IsHighlighted reports if AnnotationHighlightedProduct is true.
+func (m Metadata) IsHighlighted() bool {
+	b, _ := m.GetBool(AnnotationHighlightedProduct)
+	return b
+}
+
+// SetHighlighted sets AnnotationHighlightedProduct.
+func (m *Metadata) SetHighlighted(highlighted bool) {
+	m.Set(AnnotationHighlightedProduct, highlighted)
+}
+
+// Validate will check the values of the well-known annotations, other
+// keys are not checked.
+func (m Metadata) Validate() error {
+	v := &validator{}
+	v.metadata("metadata", m)
+	return v.err()
+}
+
+func (v *validator) metadata(field string, m Metadata) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if _, ok := annotationValidators[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if msg := annotationValidators[key](m[key]); msg != "" {
+			v.add(field+"."+key, "%s", msg)
+		}
+	}
+
+	from, okFrom := m.GetTime(AnnotationValidFrom)
+	until, okUntil := m.GetTime(AnnotationValidUntil)
+	if okFrom && okUntil && !until.After(from) {
+		v.add(field+"."+AnnotationValidUntil, "must be after %s", AnnotationValidFrom)
+	}
+}
+
+func (v *validator) highlighted(field string, m Metadata) {
+	value, ok := m[AnnotationHighlightedProduct]
+	if !ok {
+		return
+	}
+	if msg := validBool(value); msg != "" {
+		v.add(field+"."+AnnotationHighlightedProduct, "%s", msg)
+	}
+}
+
+func validBool(v interface{}) string {
+	if _, ok := (Metadata{"v": v}).GetBool("v"); !ok {
+		return fmt.Sprintf("%v is not true or false", v)
+	}
+	return ""
+}
+
+func validString(v interface{}) string {
+	if s, ok := v.(string); !ok || strings.TrimSpace(s) == "" {
+		return "must be a non-empty string"
+	}
+	return ""
+}
+
+func validTime(v interface{}) string {
+	if _, ok := (Metadata{"v": v}).GetTime("v"); !ok {
+		return fmt.Sprintf("%v is not an RFC 3339 timestamp", v)
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package capis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SelectorOperator is how a metadata selector matches a key.
+type SelectorOperator string
+
+const (
+	// SelectorEquals matches when the key has the value.
+	SelectorEquals SelectorOperator = "="
+	// SelectorExists matches when the key is set.
+	SelectorExists SelectorOperator = "exists"
+	// SelectorIn matches when the key has one of the values.
+	SelectorIn SelectorOperator = "in"
+)
+
+type (
+	// MetadataSelector selects products and groups by their metadata.
+	MetadataSelector struct {
+		Key      string
+		Operator SelectorOperator
+		Values   []string
+	}
+
+	// MetadataSelectors match when every selector matches. The product and
+	// group endpoints cannot filter by metadata so list calls apply the
+	// selectors to the results, which can leave pages with fewer items.
+	MetadataSelectors []MetadataSelector
+)
+
+// MetadataEquals returns a selector matching the key with the value.
+func MetadataEquals(key, value string) MetadataSelector {
+	return MetadataSelector{Key: key, Operator: SelectorEquals, Values: []string{value}}
+}
+
+// MetadataExists returns a selector matching the key being set.
+func MetadataExists(key string) MetadataSelector {
+	return MetadataSelector{Key: key, Operator: SelectorExists}
+}
+
+// MetadataIn returns a selector matching the key with any of the values.
+func MetadataIn(key string, values ...string) MetadataSelector {
+	return MetadataSelector{Key: key, Operator: SelectorIn, Values: values}
+}
+
+// String returns the selector as key=value, key or key in (a,b).
+func (s MetadataSelector) String() string {
+	switch s.Operator {
+	case SelectorExists:
+		return s.Key
+	case SelectorIn:
+		return s.Key + " in (" + strings.Join(s.Values, ",") + ")"
+	default:
+		return s.Key + "=" + strings.Join(s.Values, "")
+	}
+}
+
+// Matches reports if the metadata matches the selector, values that are
+// not strings are compared using their default format.
+func (s MetadataSelector) Matches(m Metadata) bool {
+	v, ok := m[s.Key]
+	if !ok {
+		return false
+	}
+
+	switch s.Operator {
+	case SelectorExists:
+		return true
+	case SelectorEquals, SelectorIn:
+		value := fmt.Sprint(v)
+		for _, want := range s.Values {
+			if value == want {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Matches reports if the metadata matches every selector.
+func (s MetadataSelectors) Matches(m Metadata) bool {
+	for _, sel := range s {
+		if !sel.Matches(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterMetadata returns the items matching the selectors.
+func filterMetadata[T any](items []T, s MetadataSelectors, meta func(T) Metadata) []T {
+	if len(s) == 0 {
+		return items
+	}
+
+	out := make([]T, 0, len(items))
+	for _, it := range items {
+		if s.Matches(meta(it)) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
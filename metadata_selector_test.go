@@ -0,0 +1,75 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataSelectors(t *testing.T) {
+	m := Metadata{"campaign": "spring", "partner": "acme", "tier": 2}
+
+	assert.True(t, MetadataSelectors{MetadataEquals("campaign", "spring"), MetadataExists("partner")}.Matches(m))
+	assert.True(t, MetadataSelectors{MetadataIn("tier", "1", "2")}.Matches(m))
+	assert.False(t, MetadataSelectors{MetadataEquals("campaign", "spring"), MetadataExists("missing")}.Matches(m))
+	assert.False(t, MetadataSelectors{MetadataIn("partner", "other")}.Matches(m))
+	assert.True(t, MetadataSelectors{}.Matches(nil))
+}
+
+func TestListMortgagesMetadataQuery(t *testing.T) {
+	var query []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()["metadata"]
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"m1","metadata":{"campaign":"spring","partner":"acme","tier":1}},
+			{"id":"m2","metadata":{"campaign":"spring","partner":"acme","tier":3}},
+			{"id":"m3","metadata":{"campaign":"autumn","partner":"acme","tier":1}},
+			{"id":"m4","metadata":{"campaign":"spring","tier":1}},
+			{"id":"m5"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	res, err := c.Products().ListMortgages(context.Background(), &MortgageProductFilters{
+		Metadata: MetadataSelectors{MetadataEquals("campaign", "spring"), MetadataExists("partner"), MetadataIn("tier", "1", "2")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, query, "the endpoint cannot filter by metadata")
+	if assert.Len(t, res.Data, 1) {
+		assert.Equal(t, "m1", res.Data[0].ID)
+	}
+
+	res, err = c.Products().ListMortgages(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, res.Data, 5)
+}
+
+func TestListGroupsMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query()["metadata"])
+		_, _ = w.Write([]byte(`{"data":[{"id":"g1","metadata":{"partner":"acme"}},{"id":"g2","metadata":{"partner":"other"}}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	res, err := c.ListGroups(context.Background(), &GroupFilters{Metadata: MetadataSelectors{MetadataIn("partner", "acme", "beta")}})
+	require.NoError(t, err)
+	if assert.Len(t, res.Data, 1) {
+		assert.Equal(t, "g1", res.Data[0].ID)
+	}
+
+	res, err = c.ListGroups(context.Background(), &GroupFilters{Metadata: MetadataSelectors{MetadataEquals("partner", "other")}})
+	require.NoError(t, err)
+	if assert.Len(t, res.Data, 1) {
+		assert.Equal(t, "g2", res.Data[0].ID)
+	}
+}
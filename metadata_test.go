@@ -0,0 +1,86 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata(t *testing.T) {
+	var m Metadata
+	assert.False(t, m.IsHighlighted())
+
+	m.SetHighlighted(true)
+	assert.True(t, m.IsHighlighted())
+
+	m[AnnotationHighlightedProduct] = "true"
+	assert.True(t, m.IsHighlighted())
+
+	m[AnnotationHighlightedProduct] = "TRUE"
+	assert.False(t, m.IsHighlighted())
+	assert.Error(t, m.Validate(), "only the exact strings true and false are valid")
+
+	m[AnnotationHighlightedProduct] = "false"
+	assert.NoError(t, m.Validate())
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.Set(AnnotationValidFrom, from)
+	got, ok := m.GetTime(AnnotationValidFrom)
+	assert.True(t, ok)
+	assert.True(t, from.Equal(got))
+
+	m.Set(AnnotationCampaign, "spring")
+	s, ok := m.GetString(AnnotationCampaign)
+	assert.True(t, ok)
+	assert.Equal(t, "spring", s)
+	assert.NoError(t, m.Validate())
+
+	m[AnnotationHighlightedProduct] = "yes"
+	m[AnnotationPartner] = 12
+	m.Set(AnnotationValidUntil, from.Add(-time.Hour))
+
+	err := m.Validate()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Errors, 3)
+	assert.Equal(t, "metadata."+AnnotationHighlightedProduct, verr.Errors[0].Field)
+}
+
+func TestUpdateMortgageRejectsMalformedAnnotations(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	err = c.Products().UpdateMortgage(context.Background(), &Mortgage{
+		ID:   "m1",
+		Meta: Metadata{AnnotationHighlightedProduct: "maybe"},
+	})
+
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.False(t, called)
+
+	freeForm := &Mortgage{ID: "m1", Meta: Metadata{AnnotationValidFrom: "next spring"}}
+	assert.NoError(t, c.Products().UpdateMortgage(context.Background(), freeForm))
+	assert.True(t, called)
+
+	strict, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")), WithValidation())
+	require.NoError(t, err)
+	require.ErrorAs(t, strict.Products().UpdateMortgage(context.Background(), freeForm), &verr)
+
+	fields := make([]string, len(verr.Errors))
+	for i, fe := range verr.Errors {
+		fields[i] = fe.Field
+	}
+	assert.Contains(t, fields, "metadata."+AnnotationValidFrom)
+}
@@ -0,0 +1,252 @@
+package capis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultMinorUnits is used for currencies missing from the ISO-4217 table.
+const DefaultMinorUnits = 2
+
+var (
+	// ErrCurrencyMismatch is returned when combining money of two currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrInvalidAmount is returned when a decimal amount cannot be parsed.
+	ErrInvalidAmount = errors.New("invalid amount")
+)
+
+type (
+	currency struct {
+		minorUnits int
+		symbol     string
+	}
+
+	// Locale describes how money is formatted.
+	Locale struct {
+		Grouping string
+		Decimal  string
+		// SymbolAfter places the currency symbol after the amount.
+		SymbolAfter bool
+	}
+)
+
+var (
+	// LocaleGB formats money as £1,234.56
+	LocaleGB = Locale{Grouping: ",", Decimal: "."}
+	// LocaleUS formats money as $1,234.56
+	LocaleUS = Locale{Grouping: ",", Decimal: "."}
+	// LocaleDE formats money as 1.234,56 €
+	LocaleDE = Locale{Grouping: ".", Decimal: ",", SymbolAfter: true}
+	// LocaleFR formats money as 1 234,56 €
+	LocaleFR = Locale{Grouping: " ", Decimal: ",", SymbolAfter: true}
+)
+
+// currencies are the ISO-4217 minor units and symbols of the currencies
+// known to the client.
+var currencies = map[string]currency{
+	"GBP": {2, "£"},
+	"EUR": {2, "€"},
+	"USD": {2, "$"},
+	"CAD": {2, "CA$"},
+	"AUD": {2, "A$"},
+	"NZD": {2, "NZ$"},
+	"CHF": {2, "CHF"},
+	"SEK": {2, "kr"},
+	"NOK": {2, "kr"},
+	"DKK": {2, "kr"},
+	"PLN": {2, "zł"},
+	"JPY": {0, "¥"},
+	"KRW": {0, "₩"},
+	"ISK": {0, "kr"},
+	"BHD": {3, "BD"},
+	"KWD": {3, "KD"},
+	"OMR": {3, "OMR"},
+}
+
+// MinorUnits returns the number of decimal places of the ISO-4217
+// currency, Money.Amount is expressed in these minor units.
+func MinorUnits(code string) int {
+	if c, ok := currencies[strings.ToUpper(code)]; ok {
+		return c.minorUnits
+	}
+	return DefaultMinorUnits
+}
+
+// ParseMoney returns money for a decimal amount such as "1234.56", the
+// amount must not have more decimal places than the currency allows.
+func ParseMoney(code, amount string) (Money, error) {
+	a, err := parseMinorUnits(amount, MinorUnits(code))
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(code, a, ""), nil
+}
+
+func parseMinorUnits(s string, units int) (int64, error) {
+	s = strings.TrimSpace(s)
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > units || strings.ContainsAny(whole+frac, "+-") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	frac += strings.Repeat("0", units-len(frac))
+
+	a, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	if neg {
+		a = -a
+	}
+	return a, nil
+}
+
+// Add returns the sum of the money.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return NewMoney(m.Currency, m.Amount+o.Amount, m.Description), nil
+}
+
+// Sub returns the money less o.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return NewMoney(m.Currency, m.Amount-o.Amount, m.Description), nil
+}
+
+// Cmp returns -1, 0 or 1 if the money is less than, equal to or more than o.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// IsZero reports if the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports if the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) sameCurrency(o Money) error {
+	if !strings.EqualFold(m.Currency, o.Currency) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+// Decimal returns the amount in major units, e.g. 1234.56 for 123456 GBP.
+func (m Money) Decimal() string {
+	return m.format(Locale{Decimal: "."})
+}
+
+// Float returns the amount in major units, it should only be used for
+// display or calculations that do not need to be exact.
+func (m Money) Float() float64 {
+	return float64(m.Amount) / math.Pow10(MinorUnits(m.Currency))
+}
+
+// Format returns the amount with its currency symbol for the locale.
+func (m Money) Format(l Locale) string {
+	symbol := strings.ToUpper(m.Currency)
+	if c, ok := currencies[symbol]; ok {
+		symbol = c.symbol
+	}
+
+	sign, amount := "", m.format(l)
+	if m.Amount < 0 {
+		sign, amount = "-", strings.TrimPrefix(amount, "-")
+	}
+
+	if l.SymbolAfter {
+		return sign + amount + " " + symbol
+	}
+	return sign + symbol + amount
+}
+
+// String formats the money for the GB locale, e.g. £1,234.56. It is also
+// used by %v and %+v, so Money and the structs containing it print the
+// formatted amount instead of the Currency, Amount and Description
+// fields, use the fields when the raw values are needed.
+func (m Money) String() string {
+	return m.Format(LocaleGB)
+}
+
+func (m Money) format(l Locale) string {
+	units := MinorUnits(m.Currency)
+
+	sign, digits := "", strconv.FormatInt(m.Amount, 10)
+	if m.Amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+
+	whole, frac := digits[:len(digits)-units], digits[len(digits)-units:]
+
+	if l.Grouping != "" {
+		var groups []string
+		for len(whole) > 3 {
+			groups = append([]string{whole[len(whole)-3:]}, groups...)
+			whole = whole[:len(whole)-3]
+		}
+		whole = strings.Join(append([]string{whole}, groups...), l.Grouping)
+	}
+
+	if units == 0 {
+		return sign + whole
+	}
+	return sign + whole + l.Decimal + frac
+}
+
+// UnmarshalJSON accepts the amount in minor units or as a decimal string
+// in major units, e.g. 123456 or "1234.56".
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Currency    string          `json:"currency"`
+		Amount      json.RawMessage `json:"amount"`
+		Description string          `json:"description"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	m.Currency, m.Description, m.Amount = raw.Currency, raw.Description, 0
+
+	if len(raw.Amount) == 0 || string(raw.Amount) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw.Amount, &s); err == nil {
+		a, err := parseMinorUnits(s, MinorUnits(raw.Currency))
+		m.Amount = a
+		return err
+	}
+
+	return json.Unmarshal(raw.Amount, &m.Amount)
+}
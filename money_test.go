@@ -0,0 +1,84 @@
+package capis
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := NewMoney("GBP", 150, "")
+
+	sum, err := a.Add(NewMoney("GBP", 250, ""))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(400), sum.Amount)
+
+	diff, err := a.Sub(NewMoney("GBP", 250, ""))
+	assert.NoError(t, err)
+	assert.True(t, diff.IsNegative())
+
+	cmp, err := a.Cmp(NewMoney("gbp", 100, ""))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	_, err = a.Add(NewMoney("EUR", 1, ""))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	for in, expected := range map[string]Money{
+		"1234.56": NewMoney("GBP", 123456, ""),
+		"-0.05":   NewMoney("GBP", -5, ""),
+		"12":      NewMoney("GBP", 1200, ""),
+	} {
+		m, err := ParseMoney("GBP", in)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, m)
+	}
+
+	m, err := ParseMoney("JPY", "1500")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500), m.Amount)
+
+	_, err = ParseMoney("GBP", "1.234")
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = ParseMoney("JPY", "1.5")
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	assert.Equal(t, "1234.56", NewMoney("GBP", 123456, "").Decimal())
+	assert.Equal(t, "0.05", NewMoney("GBP", 5, "").Decimal())
+	assert.Equal(t, "1.500", NewMoney("KWD", 1500, "").Decimal())
+}
+
+func TestMoneyFormat(t *testing.T) {
+	assert.Equal(t, "£1,234.56", NewMoney("GBP", 123456, "").String())
+	assert.Equal(t, "-£0.99", NewMoney("GBP", -99, "").String())
+	assert.Equal(t, "1.234.567,89 €", NewMoney("EUR", 123456789, "").Format(LocaleDE))
+	assert.Equal(t, "1 000,00 €", NewMoney("EUR", 100000, "").Format(LocaleFR))
+	assert.Equal(t, "¥1,500", NewMoney("JPY", 1500, "").Format(LocaleGB))
+	assert.Equal(t, "XYZ10.00", NewMoney("XYZ", 1000, "").String())
+
+	fee := struct{ Cost Money }{NewMoney("GBP", 99, "")}
+	assert.Equal(t, "{Cost:£0.99}", fmt.Sprintf("%+v", fee), "%+v uses String")
+}
+
+func TestMoneyJSON(t *testing.T) {
+	in := NewMoney("GBP", 123456, "up to")
+
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"currency":"GBP","amount":123456,"description":"up to"}`, string(b))
+
+	var out Money
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, in, out)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"currency":"GBP","amount":"1234.56"}`), &out))
+	assert.Equal(t, int64(123456), out.Amount)
+
+	fee := Fee{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"fixed":null,"variable":1.5}`), &fee))
+	assert.Nil(t, fee.Fixed)
+}
@@ -0,0 +1,74 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type (
+	// RequestEvent describes a request sent by the client.
+	RequestEvent struct {
+		Request *http.Request
+		// Route is the path template of the request, e.g. /v2/mortgages/{id}
+		Route    string
+		Response *http.Response
+		Err      error
+		Attempt  int
+		Elapsed  time.Duration
+		// Wait is how long the client waits before the next attempt.
+		Wait time.Duration
+	}
+
+	// Observer is notified about the lifecycle of the requests sent by the
+	// client, implementations must be safe for concurrent use.
+	Observer interface {
+		// RequestStarted is called before the request is sent, the context
+		// returned is used for the request.
+		RequestStarted(context.Context, *RequestEvent) context.Context
+		RequestFinished(context.Context, *RequestEvent)
+		RequestRetried(context.Context, *RequestEvent)
+		AuthRefreshed(context.Context, error)
+	}
+
+	routeKey       struct{}
+	authRefreshKey struct{}
+)
+
+// WithObserver returns an option to pass to New()
+func WithObserver(o Observer) Option {
+	return func(c *Client) error {
+		c.observers = append(c.observers, o)
+		return nil
+	}
+}
+
+func withRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+// routeFromRequest will return the route the request was created for or
+// the path for requests not created by the client.
+func routeFromRequest(req *http.Request) string {
+	if r, ok := req.Context().Value(routeKey{}).(string); ok {
+		return r
+	}
+	return req.URL.Path
+}
+
+func withAuthRefreshHook(ctx context.Context, f func(context.Context, error)) context.Context {
+	return context.WithValue(ctx, authRefreshKey{}, f)
+}
+
+// authRefreshed is called by the auth providers when they fetch a new token.
+func authRefreshed(ctx context.Context, err error) {
+	if f, ok := ctx.Value(authRefreshKey{}).(func(context.Context, error)); ok {
+		f(ctx, err)
+	}
+}
+
+func (c *Client) authRefreshed(ctx context.Context, err error) {
+	for _, o := range c.observers {
+		o.AuthRefreshed(ctx, err)
+	}
+}
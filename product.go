@@ -0,0 +1,247 @@
+package capis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Product is implemented by each of the product types.
+type Product interface {
+	ProductType() ProductType
+	GetID() string
+	GetIssuer() string
+	GetName() string
+	GetDescription() string
+	GetURLApply() string
+	GetURLLogo() string
+	GetHighlightedPoints() []string
+	IsActive() bool
+	GetMeta() Metadata
+}
+
+// ErrUnsupportedProductType is returned when the product type cannot be
+// used, e.g. credit cards have no product representation.
+var ErrUnsupportedProductType = errors.New("unsupported product type")
+
+var (
+	_ Product = (*Loan)(nil)
+	_ Product = (*Mortgage)(nil)
+	_ Product = (*BankAccount)(nil)
+)
+
+// FindProduct will return the product of the type.
+func (s *ProductsService) FindProduct(ctx context.Context, t ProductType, id string) (Product, error) {
+	var (
+		p   Product
+		err error
+	)
+
+	switch t {
+	case TypeLoan:
+		p, err = s.FindLoan(ctx, id)
+	case TypeMortgage:
+		p, err = s.FindMortgage(ctx, id)
+	case TypeBankAccount:
+		p, err = s.FindBankAccount(ctx, id)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProductType, t)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DecodeProduct decodes the JSON of a product of the type.
+func DecodeProduct(t ProductType, b []byte) (Product, error) {
+	var p Product
+	switch t {
+	case TypeLoan:
+		p = &Loan{}
+	case TypeMortgage:
+		p = &Mortgage{}
+	case TypeBankAccount:
+		p = &BankAccount{}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProductType, t)
+	}
+
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DecodeProducts decodes a JSON array of products of mixed types. The
+// type of each product is read from its product_type field, when it is
+// missing the type is detected from the fields only one type has. Unknown
+// enum values are kept, see Client.DecodeProducts to reject them.
+func DecodeProducts(b []byte) ([]Product, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	out := make([]Product, 0, len(raw))
+	for i, r := range raw {
+		t, err := detectProductType(r)
+		if err != nil {
+			return nil, fmt.Errorf("product %d %w", i, err)
+		}
+
+		p, err := DecodeProduct(t, r)
+		if err != nil {
+			return nil, fmt.Errorf("product %d %w", i, err)
+		}
+		out = append(out, p)
+	}
+
+	return out, nil
+}
+
+// DecodeProducts decodes a JSON array of products of mixed types like
+// DecodeProducts, in EnumStrict mode products with unknown enum values
+// return an error wrapping ErrUnknownEnum.
+func (c *Client) DecodeProducts(b []byte) ([]Product, error) {
+	products, err := DecodeProducts(b)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, p := range products {
+		if err := c.checkEnums(p); err != nil {
+			return nil, fmt.Errorf("product %d %w", i, err)
+		}
+	}
+	return products, nil
+}
+
+// productTypeFields are fields only found on one product type.
+var productTypeFields = []struct {
+	field string
+	t     ProductType
+}{
+	{"loan_to_value", TypeMortgage},
+	{"early_redemption_charge", TypeMortgage},
+	{"interest_paid", TypeBankAccount},
+	{"deposit_minimum", TypeBankAccount},
+	{"setup_fee", TypeLoan},
+	{"guarantor_allowed", TypeLoan},
+}
+
+func detectProductType(b []byte) (ProductType, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return "", err
+	}
+
+	if raw, ok := fields["product_type"]; ok {
+		var t ProductType
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return "", err
+		}
+		return t, nil
+	}
+
+	for _, f := range productTypeFields {
+		if _, ok := fields[f.field]; ok {
+			return f.t, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unable to detect the type of %s", ErrUnsupportedProductType, bytes.TrimSpace(b))
+}
+
+// ProductType returns TypeLoan.
+func (l *Loan) ProductType() ProductType { return TypeLoan }
+
+// GetID returns the ID.
+func (l *Loan) GetID() string { return l.ID }
+
+// GetIssuer returns the Issuer.
+func (l *Loan) GetIssuer() string { return l.Issuer }
+
+// GetName returns the Name.
+func (l *Loan) GetName() string { return l.Name }
+
+// GetDescription returns the Description.
+func (l *Loan) GetDescription() string { return l.Description }
+
+// GetURLApply returns the URLApply.
+func (l *Loan) GetURLApply() string { return l.URLApply }
+
+// GetURLLogo returns the URLLogo.
+func (l *Loan) GetURLLogo() string { return l.URLLogo }
+
+// GetHighlightedPoints returns the HighlightedPoints.
+func (l *Loan) GetHighlightedPoints() []string { return l.HighlightedPoints }
+
+// IsActive returns Active.
+func (l *Loan) IsActive() bool { return l.Active }
+
+// GetMeta returns the Meta.
+func (l *Loan) GetMeta() Metadata { return l.Meta }
+
+// ProductType returns TypeMortgage.
+func (m *Mortgage) ProductType() ProductType { return TypeMortgage }
+
+// GetID returns the ID.
+func (m *Mortgage) GetID() string { return m.ID }
+
+// GetIssuer returns the Issuer.
+func (m *Mortgage) GetIssuer() string { return m.Issuer }
+
+// GetName returns the Name.
+func (m *Mortgage) GetName() string { return m.Name }
+
+// GetDescription returns the Description.
+func (m *Mortgage) GetDescription() string { return m.Description }
+
+// GetURLApply returns the URLApply.
+func (m *Mortgage) GetURLApply() string { return m.URLApply }
+
+// GetURLLogo returns the URLLogo.
+func (m *Mortgage) GetURLLogo() string { return m.URLLogo }
+
+// GetHighlightedPoints returns the HighlightedPoints.
+func (m *Mortgage) GetHighlightedPoints() []string { return m.HighlightedPoints }
+
+// IsActive returns Active.
+func (m *Mortgage) IsActive() bool { return m.Active }
+
+// GetMeta returns the Meta.
+func (m *Mortgage) GetMeta() Metadata { return m.Meta }
+
+// ProductType returns TypeBankAccount.
+func (b *BankAccount) ProductType() ProductType { return TypeBankAccount }
+
+// GetID returns the ID.
+func (b *BankAccount) GetID() string { return b.ID }
+
+// GetIssuer returns the Issuer.
+func (b *BankAccount) GetIssuer() string { return b.Issuer }
+
+// GetName returns the Name.
+func (b *BankAccount) GetName() string { return b.Name }
+
+// GetDescription returns the Description.
+func (b *BankAccount) GetDescription() string { return b.Description }
+
+// GetURLApply returns the URLApply.
+func (b *BankAccount) GetURLApply() string { return b.URLApply }
+
+// GetURLLogo returns the URLLogo.
+func (b *BankAccount) GetURLLogo() string { return b.URLLogo }
+
+// GetHighlightedPoints returns the HighlightedPoints.
+func (b *BankAccount) GetHighlightedPoints() []string { return b.HighlightedPoints }
+
+// IsActive returns Active.
+func (b *BankAccount) IsActive() bool { return b.Active }
+
+// GetMeta returns the Meta.
+func (b *BankAccount) GetMeta() Metadata { return b.Meta }
@@ -0,0 +1,58 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeProducts(t *testing.T) {
+	products, err := DecodeProducts([]byte(`[
+		{"id":"l1","setup_fee":{"variable":1}},
+		{"id":"m1","loan_to_value":{"value":75}},
+		{"id":"b1","interest_paid":"monthly"},
+		{"id":"m2","product_type":"mortgage"}
+	]`))
+	require.NoError(t, err)
+
+	types := make([]ProductType, len(products))
+	for i, p := range products {
+		types[i] = p.ProductType()
+	}
+	assert.Equal(t, []ProductType{TypeLoan, TypeMortgage, TypeBankAccount, TypeMortgage}, types)
+	assert.Equal(t, "b1", products[2].GetID())
+	assert.Equal(t, InterestPaidMonthly, products[2].(*BankAccount).InterestPaid)
+
+	_, err = DecodeProducts([]byte(`[{"id":"x"}]`))
+	assert.ErrorIs(t, err, ErrUnsupportedProductType)
+}
+
+func TestFindProduct(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/loans/l1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"l1","name":"Loan"}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+	require.NoError(t, err)
+
+	p, err := c.Products().FindProduct(context.Background(), TypeLoan, "l1")
+	require.NoError(t, err)
+	assert.Equal(t, TypeLoan, p.ProductType())
+	assert.Equal(t, "Loan", p.GetName())
+
+	p, err = c.Products().FindProduct(context.Background(), TypeMortgage, "l1")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, p)
+
+	_, err = c.Products().FindProduct(context.Background(), TypeCreditCard, "c1")
+	assert.ErrorIs(t, err, ErrUnsupportedProductType)
+}
@@ -8,6 +8,8 @@ type (
 
 	// ProductFilters ...
 	ProductFilters struct {
+		ID       []string          `url:"id,comma,omitempty"`
+		Metadata MetadataSelectors `url:"-"`
 	}
 )
 
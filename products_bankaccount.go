@@ -5,73 +5,71 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	querystring "github.com/google/go-querystring/query"
-	"go.opencensus.io/trace"
 )
 
 type (
 	NewBankAccountRequest struct {
-		ID                    string                 `json:"id"`
-		Issuer                string                 `json:"issuer"`
-		Name                  string                 `json:"name"`
-		Description           string                 `json:"description"`
-		URLApply              string                 `json:"url_apply"`
-		URLLogo               string                 `json:"url_logo"`
-		HighlightedPoints     []string               `json:"highlighted_points"`
-		TechnicalPoints       []string               `json:"technical_points"`
-		OfferInterestRate     RatePeriod             `json:"offer_interest_rate"`
-		StandardInterestRate  Rate                   `json:"standard_interest_rate"`
-		InterestPaid          string                 `json:"interest_paid"`
-		OfferOverdraftRate    RatePeriod             `json:"offer_overdraft_rate"`
-		StandardOverdraftRate Rate                   `json:"standard_overdraft_rate"`
-		StandardChargeRate    Rate                   `json:"standard_charge_rate"`
-		OfferChargeRate       Rate                   `json:"offer_charge_rate"`
-		MinimumDeposit        Money                  `json:"deposit_minimum"`
-		MaximumDeposit        Money                  `json:"deposit_maximum"`
-		AnnualFee             Money                  `json:"annual_fee"`
-		MonthlyFee            Money                  `json:"monthly_fee"`
-		ApprovalCriteria      string                 `json:"approval_criteria"`
-		IsISA                 bool                   `json:"is_isa"`
-		IsCapitalProtected    bool                   `json:"is_capital_protected"`
-		HasTransactionFees    bool                   `json:"has_transaction_fees"`
-		HasOnlineBanking      bool                   `json:"has_online_banking"`
-		BrokerOnly            bool                   `json:"broker_only"`
-		Active                bool                   `json:"active"`
-		Meta                  map[string]interface{} `json:"metadata"`
+		ID                    string       `json:"id"`
+		Issuer                string       `json:"issuer"`
+		Name                  string       `json:"name"`
+		Description           string       `json:"description"`
+		URLApply              string       `json:"url_apply"`
+		URLLogo               string       `json:"url_logo"`
+		HighlightedPoints     []string     `json:"highlighted_points"`
+		TechnicalPoints       []string     `json:"technical_points"`
+		OfferInterestRate     RatePeriod   `json:"offer_interest_rate"`
+		StandardInterestRate  Rate         `json:"standard_interest_rate"`
+		InterestPaid          InterestPaid `json:"interest_paid"`
+		OfferOverdraftRate    RatePeriod   `json:"offer_overdraft_rate"`
+		StandardOverdraftRate Rate         `json:"standard_overdraft_rate"`
+		StandardChargeRate    Rate         `json:"standard_charge_rate"`
+		OfferChargeRate       Rate         `json:"offer_charge_rate"`
+		MinimumDeposit        Money        `json:"deposit_minimum"`
+		MaximumDeposit        Money        `json:"deposit_maximum"`
+		AnnualFee             Money        `json:"annual_fee"`
+		MonthlyFee            Money        `json:"monthly_fee"`
+		ApprovalCriteria      string       `json:"approval_criteria"`
+		IsISA                 bool         `json:"is_isa"`
+		IsCapitalProtected    bool         `json:"is_capital_protected"`
+		HasTransactionFees    bool         `json:"has_transaction_fees"`
+		HasOnlineBanking      bool         `json:"has_online_banking"`
+		BrokerOnly            bool         `json:"broker_only"`
+		Active                bool         `json:"active"`
+		Meta                  Metadata     `json:"metadata"`
 	}
 
 	BankAccount struct {
-		ID                    string                 `json:"id"`
-		Issuer                string                 `json:"issuer"`
-		Name                  string                 `json:"name"`
-		Description           string                 `json:"description"`
-		URLApply              string                 `json:"url_apply"`
-		URLLogo               string                 `json:"url_logo"`
-		HighlightedPoints     []string               `json:"highlighted_points"`
-		TechnicalPoints       []string               `json:"technical_points"`
-		OfferInterestRate     RatePeriod             `json:"offer_interest_rate"`
-		StandardInterestRate  Rate                   `json:"standard_interest_rate"`
-		InterestPaid          string                 `json:"interest_paid"`
-		OfferOverdraftRate    RatePeriod             `json:"offer_overdraft_rate"`
-		StandardOverdraftRate Rate                   `json:"standard_overdraft_rate"`
-		StandardChargeRate    Rate                   `json:"standard_charge_rate"`
-		OfferChargeRate       Rate                   `json:"offer_charge_rate"`
-		MinimumDeposit        Money                  `json:"deposit_minimum"`
-		MaximumDeposit        Money                  `json:"deposit_maximum"`
-		AnnualFee             Money                  `json:"annual_fee"`
-		MonthlyFee            Money                  `json:"monthly_fee"`
-		ApprovalCriteria      string                 `json:"approval_criteria"`
-		IsISA                 bool                   `json:"is_isa"`
-		IsCapitalProtected    bool                   `json:"is_capital_protected"`
-		HasTransactionFees    bool                   `json:"has_transaction_fees"`
-		HasOnlineBanking      bool                   `json:"has_online_banking"`
-		BrokerOnly            bool                   `json:"broker_only"`
-		Active                bool                   `json:"active"`
-		Meta                  map[string]interface{} `json:"metadata"`
-		Created               time.Time              `json:"created"`
+		ID                    string       `json:"id"`
+		Issuer                string       `json:"issuer"`
+		Name                  string       `json:"name"`
+		Description           string       `json:"description"`
+		URLApply              string       `json:"url_apply"`
+		URLLogo               string       `json:"url_logo"`
+		HighlightedPoints     []string     `json:"highlighted_points"`
+		TechnicalPoints       []string     `json:"technical_points"`
+		OfferInterestRate     RatePeriod   `json:"offer_interest_rate"`
+		StandardInterestRate  Rate         `json:"standard_interest_rate"`
+		InterestPaid          InterestPaid `json:"interest_paid"`
+		OfferOverdraftRate    RatePeriod   `json:"offer_overdraft_rate"`
+		StandardOverdraftRate Rate         `json:"standard_overdraft_rate"`
+		StandardChargeRate    Rate         `json:"standard_charge_rate"`
+		OfferChargeRate       Rate         `json:"offer_charge_rate"`
+		MinimumDeposit        Money        `json:"deposit_minimum"`
+		MaximumDeposit        Money        `json:"deposit_maximum"`
+		AnnualFee             Money        `json:"annual_fee"`
+		MonthlyFee            Money        `json:"monthly_fee"`
+		ApprovalCriteria      string       `json:"approval_criteria"`
+		IsISA                 bool         `json:"is_isa"`
+		IsCapitalProtected    bool         `json:"is_capital_protected"`
+		HasTransactionFees    bool         `json:"has_transaction_fees"`
+		HasOnlineBanking      bool         `json:"has_online_banking"`
+		BrokerOnly            bool         `json:"broker_only"`
+		Active                bool         `json:"active"`
+		Meta                  Metadata     `json:"metadata"`
+		Created               time.Time    `json:"created"`
 	}
 
 	ListBankAccountsResponse struct {
@@ -80,85 +78,115 @@ type (
 )
 
 func (s *ProductsService) NewBankAccount(ctx context.Context, opts *NewBankAccountRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.NewBankAccount")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.NewBankAccount")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, opts.ID)
+
+	if err := s.c.validateProduct(opts, opts.Meta); err != nil {
+		return err
+	}
 
 	rb, _ := json.Marshal(opts)
-	req, err := s.c.newRequest("POST", "/v1/bankaccounts", bytes.NewReader(rb))
+	req, err := s.c.newRequest(ctx, "POST", "/v1/bankaccounts", bytes.NewReader(rb))
 
 	if err != nil {
+		s.c.logError(err)
 		return err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return ErrUnreachable
 	}
 	defer res.Body.Close()
 
-	return statusCodeToError(res.StatusCode)
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return err
+	}
+
+	return nil
 }
 
 func (s *ProductsService) FindBankAccount(ctx context.Context, id string) (*BankAccount, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.FinkBankAccount")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.FindBankAccount")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, id)
 
-	req, err := s.c.newRequest("GET", fmt.Sprintf("/v1/bankaccounts/%s", id), nil)
+	req, err := s.c.newRequest(ctx, "GET", "/v1/bankaccounts/{id}", nil, id)
 
 	if err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return nil, ErrUnreachable
 	}
 	defer res.Body.Close()
 
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return nil, err
+	}
+
 	ba := &BankAccount{}
-	return ba, unmarshalResponse(res, ba)
+	return ba, s.c.decode(res, ba)
 }
 
 func (s *ProductsService) UpdateBankAccount(ctx context.Context, bankAccount *BankAccount) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.UpdateBankAccount")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.UpdateBankAccount")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, bankAccount.ID)
 
 	if len(bankAccount.ID) == 0 {
 		return errors.New("can only update an existing bank account")
 	}
 
+	if err := s.c.validateProduct(bankAccount, bankAccount.Meta); err != nil {
+		return err
+	}
+
 	rb, _ := json.Marshal(bankAccount)
-	req, err := s.c.newRequest("PUT", fmt.Sprintf("/v1/bankaccounts/%s", bankAccount.ID), bytes.NewReader(rb))
+	req, err := s.c.newRequest(ctx, "PUT", "/v1/bankaccounts/{id}", bytes.NewReader(rb), bankAccount.ID)
 
 	if err != nil {
+		s.c.logError(err)
 		return err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return ErrUnreachable
 	}
 	defer res.Body.Close()
 
-	return statusCodeToError(res.StatusCode)
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return err
+	}
+
+	return nil
 }
 
 func (s *ProductsService) ListBankAccounts(ctx context.Context, filters *ProductFilters) (*ListBankAccountsResponse, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.ListBankAccounts")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.ListBankAccounts")
 	defer span.End()
 
 	obj := &ListBankAccountsResponse{}
 	qs, _ := querystring.Values(filters)
 
-	req, err := s.c.newRequest("GET", "/v1/bankaccounts?"+qs.Encode(), nil)
+	req, err := s.c.newRequest(ctx, "GET", "/v1/bankaccounts?"+qs.Encode(), nil)
 	if err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return nil, ErrUnreachable
@@ -166,8 +194,15 @@ func (s *ProductsService) ListBankAccounts(ctx context.Context, filters *Product
 	defer res.Body.Close()
 
 	if err = statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	return obj, unmarshalResponse(res, obj)
+	if err := s.c.decode(res, obj); err != nil {
+		return obj, err
+	}
+	if filters != nil {
+		obj.Data = filterMetadata(obj.Data, filters.Metadata, (*BankAccount).GetMeta)
+	}
+	return obj, nil
 }
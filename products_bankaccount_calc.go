@@ -0,0 +1,140 @@
+package capis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnknownInterestPaid is returned when the interest paid frequency of a
+// bank account is not known.
+var ErrUnknownInterestPaid = errors.New("unknown interest paid frequency")
+
+// SavingsProjection is the return of a deposit in a bank account.
+type SavingsProjection struct {
+	Deposit Money `json:"deposit"`
+	// OfferMonths is the number of months at the offer rate.
+	OfferMonths int64 `json:"offer_months"`
+	// OfferInterest and StandardInterest are the interest earned during
+	// the offer and standard periods.
+	OfferInterest    Money `json:"offer_interest"`
+	StandardInterest Money `json:"standard_interest"`
+	Interest         Money `json:"interest"`
+	Fees             Money `json:"fees"`
+	// GrossBalance is the deposit plus interest less fees before tax.
+	GrossBalance Money `json:"gross_balance"`
+	// OfferAER and StandardAER are the annual equivalent rates of the
+	// interest rates for the frequency interest is paid.
+	OfferAER    float64 `json:"offer_aer"`
+	StandardAER float64 `json:"standard_aer"`
+	// AER is the annualised return of the deposit over the horizon, it is
+	// -100 when the fees use up the whole deposit.
+	AER float64 `json:"aer"`
+}
+
+// Project returns the interest earned on the deposit over the horizon, the
+// offer rate applies for the offer period and the standard rate for the
+// rest of the horizon. Interest is added to the balance when it is paid,
+// interest that has not been paid by the end of the horizon is added then.
+// The monthly fee is taken each month and the annual fee each year.
+func (b *BankAccount) Project(deposit Money, horizon Months) (*SavingsProjection, error) {
+	if err := checkAmount(deposit, b.MinimumDeposit, b.MaximumDeposit); err != nil {
+		return nil, err
+	}
+	if horizon.Value <= 0 {
+		return nil, fmt.Errorf("%w: %d months must be more than zero", ErrTermOutOfRange, horizon.Value)
+	}
+
+	var paidEvery int64
+	switch b.InterestPaid.known() {
+	case InterestPaidMonthly:
+		paidEvery = 1
+	case InterestPaidAnnually:
+		paidEvery = 12
+	case InterestPaidOnMaturity:
+		paidEvery = horizon.Value
+	default:
+		if b.InterestPaid != "" {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownInterestPaid, b.InterestPaid)
+		}
+		paidEvery = 12
+	}
+
+	monthlyFee, annualFee := b.MonthlyFee, b.AnnualFee
+	for _, fee := range []Money{monthlyFee, annualFee} {
+		if fee.Amount != 0 && fee.Currency != "" {
+			if err := deposit.sameCurrency(fee); err != nil {
+				return nil, fmt.Errorf("fee %w", err)
+			}
+		}
+	}
+
+	timesAYear := 12 / float64(paidEvery)
+
+	p := &SavingsProjection{
+		Deposit:     deposit,
+		OfferMonths: b.OfferInterestRate.Period.Value,
+		OfferAER:    aer(b.OfferInterestRate.Value, timesAYear),
+		StandardAER: aer(b.StandardInterestRate.Value, timesAYear),
+	}
+	if p.OfferMonths < 0 || b.OfferInterestRate.Value == 0 {
+		p.OfferMonths = 0
+	}
+	if p.OfferMonths > horizon.Value {
+		p.OfferMonths = horizon.Value
+	}
+
+	balance := float64(deposit.Amount)
+	var accrued, offerInterest, standardInterest, fees float64
+
+	for month := int64(1); month <= horizon.Value; month++ {
+		rate := b.StandardInterestRate.Value
+		if month <= p.OfferMonths {
+			rate = b.OfferInterestRate.Value
+		}
+
+		interest := balance * rate / 100 / 12
+		accrued += interest
+		if month <= p.OfferMonths {
+			offerInterest += interest
+		} else {
+			standardInterest += interest
+		}
+
+		if month%paidEvery == 0 || month == horizon.Value {
+			balance += accrued
+			accrued = 0
+		}
+
+		fee := float64(monthlyFee.Amount)
+		if month%12 == 0 {
+			fee += float64(annualFee.Amount)
+		}
+		balance -= fee
+		fees += fee
+	}
+
+	p.OfferInterest = NewMoney(deposit.Currency, int64(math.Round(offerInterest)), "")
+	p.StandardInterest = NewMoney(deposit.Currency, int64(math.Round(standardInterest)), "")
+	p.Interest = NewMoney(deposit.Currency, p.OfferInterest.Amount+p.StandardInterest.Amount, "")
+	p.Fees = NewMoney(deposit.Currency, int64(fees), "")
+	p.GrossBalance = NewMoney(deposit.Currency, deposit.Amount+p.Interest.Amount-p.Fees.Amount, "")
+
+	p.AER = -100
+	if growth := float64(p.GrossBalance.Amount) / float64(deposit.Amount); growth > 0 {
+		p.AER = roundPercent((math.Pow(growth, 12/float64(horizon.Value)) - 1) * 100)
+	}
+
+	return p, nil
+}
+
+// aer returns the annual equivalent rate of the rate paid times a year,
+// times is less than one when interest is paid less than once a year.
+func aer(rate float64, times float64) float64 {
+	return roundPercent((math.Pow(1+rate/100/times, times) - 1) * 100)
+}
+
+// roundPercent rounds the percentage to two decimal places.
+func roundPercent(p float64) float64 {
+	return math.Round(p*100) / 100
+}
@@ -0,0 +1,65 @@
+package capis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBankAccountProject(t *testing.T) {
+	b := &BankAccount{
+		OfferInterestRate:    NewRatePeriod(5, "", NewMonths(12, "")),
+		StandardInterestRate: NewRate(2, ""),
+		InterestPaid:         InterestPaidAnnually,
+		MaximumDeposit:       NewMoney("GBP", 8500000, ""),
+	}
+
+	p, err := b.Project(NewMoney("GBP", 1000000, ""), NewMonths(24, ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(12), p.OfferMonths)
+	assert.Equal(t, int64(50000), p.OfferInterest.Amount)
+	assert.Equal(t, int64(21000), p.StandardInterest.Amount)
+	assert.Equal(t, int64(1071000), p.GrossBalance.Amount)
+	assert.Equal(t, 5.0, p.OfferAER)
+
+	b.InterestPaid = InterestPaidMonthly
+	b.MonthlyFee = NewMoney("GBP", 100, "")
+
+	monthly, err := b.Project(NewMoney("GBP", 1000000, ""), NewMonths(12, ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, 5.12, monthly.OfferAER)
+	assert.Equal(t, int64(1200), monthly.Fees.Amount)
+	assert.Equal(t, 1000000+monthly.Interest.Amount-1200, monthly.GrossBalance.Amount)
+
+	b.InterestPaid = InterestPaidOnMaturity
+	b.MonthlyFee = Money{}
+
+	maturity, err := b.Project(NewMoney("GBP", 1000000, ""), NewMonths(24, ""))
+	require.NoError(t, err)
+	assert.Equal(t, 4.88, maturity.OfferAER)
+
+	maturity, err = b.Project(NewMoney("GBP", 1000000, ""), NewMonths(6, ""))
+	require.NoError(t, err)
+	assert.Equal(t, 5.06, maturity.OfferAER)
+
+	b.MonthlyFee = NewMoney("GBP", 1000, "")
+
+	costly, err := b.Project(NewMoney("GBP", 5000, ""), NewMonths(12, ""))
+	require.NoError(t, err)
+	assert.Negative(t, costly.GrossBalance.Amount)
+	assert.Equal(t, float64(-100), costly.AER)
+
+	_, err = json.Marshal(costly)
+	assert.NoError(t, err)
+
+	b.InterestPaid = "weekly"
+	_, err = b.Project(NewMoney("GBP", 1000000, ""), NewMonths(12, ""))
+	assert.ErrorIs(t, err, ErrUnknownInterestPaid)
+
+	_, err = b.Project(NewMoney("GBP", 9000000, ""), NewMonths(12, ""))
+	assert.ErrorIs(t, err, ErrAmountOutOfRange)
+}
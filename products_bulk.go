@@ -0,0 +1,319 @@
+package capis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBulkConcurrency is the number of products imported at once unless
+// BulkOptions.Concurrency is set.
+const DefaultBulkConcurrency = 4
+
+// BulkAction is what happened to an imported product.
+type BulkAction string
+
+const (
+	// BulkCreated the product did not exist and was created.
+	BulkCreated BulkAction = "created"
+	// BulkUpdated the product existed and was updated.
+	BulkUpdated BulkAction = "updated"
+	// BulkFailed the product could not be imported, see BulkResult.Err.
+	BulkFailed BulkAction = "failed"
+)
+
+type (
+	// BulkItem is a single product to import, only one of the requests
+	// should be set.
+	BulkItem struct {
+		Loan        *NewLoanRequest
+		Mortgage    *NewMortgageRequest
+		BankAccount *NewBankAccountRequest
+	}
+
+	// BulkOptions customise how the products are imported.
+	BulkOptions struct {
+		// Concurrency is the number of products imported at once.
+		Concurrency int
+		// Upsert will update products that already exist instead of
+		// failing to create them.
+		Upsert bool
+	}
+
+	// BulkResult is the outcome of importing the item at Index.
+	BulkResult struct {
+		Index  int
+		ID     string
+		Type   ProductType
+		Action BulkAction
+		Err    error
+	}
+
+	// BulkReport contains the results of ImportProducts in the order of
+	// the items given.
+	BulkReport struct {
+		Results []*BulkResult
+	}
+
+	indexedBulkItem struct {
+		index int
+		item  BulkItem
+	}
+)
+
+// ErrEmptyBulkItem is returned for items that have no request set.
+var ErrEmptyBulkItem = errors.New("bulk item has no product request")
+
+// BulkLoan returns a bulk item for the loan request.
+func BulkLoan(r *NewLoanRequest) BulkItem {
+	return BulkItem{Loan: r}
+}
+
+// BulkMortgage returns a bulk item for the mortgage request.
+func BulkMortgage(r *NewMortgageRequest) BulkItem {
+	return BulkItem{Mortgage: r}
+}
+
+// BulkBankAccount returns a bulk item for the bank account request.
+func BulkBankAccount(r *NewBankAccountRequest) BulkItem {
+	return BulkItem{BankAccount: r}
+}
+
+// Failed returns the results of the items that could not be imported.
+func (r *BulkReport) Failed() []*BulkResult {
+	out := make([]*BulkResult, 0)
+	for _, res := range r.Results {
+		if res.Err != nil {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// Err returns an error joining the errors of the failed items or nil when
+// all of the items were imported.
+func (r *BulkReport) Err() error {
+	var errs []error
+	for _, res := range r.Failed() {
+		errs = append(errs, fmt.Errorf("item %d (%s %s): %w", res.Index, res.Type, res.ID, res.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// ImportProducts will create the products with bounded concurrency and
+// return the result of each item. Items already being imported when ctx
+// is done report their actual outcome, items that were not started fail
+// with the context error.
+func (s *ProductsService) ImportProducts(ctx context.Context, items []BulkItem, opts BulkOptions) *BulkReport {
+	in := make(chan BulkItem)
+	go func() {
+		defer close(in)
+		for _, item := range items {
+			select {
+			case in <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	report := &BulkReport{Results: make([]*BulkResult, len(items))}
+	for res := range s.ImportProductsStream(ctx, in, opts) {
+		report.Results[res.Index] = res
+	}
+
+	for i, res := range report.Results {
+		if res == nil {
+			report.Results[i] = notStarted(ctx, i, items[i])
+		}
+	}
+
+	return report
+}
+
+// ImportProductsStream will create the products read from items until it is
+// closed, the results are sent as each item completes and the returned
+// channel is closed once all of the items are done. Items read after ctx
+// is done fail with the context error.
+//
+// Once ctx is done no more items are read, even when items is not closed,
+// and the channel is closed after the items already started complete. The
+// result of every started item is sent so the caller must drain the
+// returned channel until it is closed.
+func (s *ProductsService) ImportProductsStream(ctx context.Context, items <-chan BulkItem, opts BulkOptions) <-chan *BulkResult {
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultBulkConcurrency
+	}
+
+	indexed := make(chan indexedBulkItem)
+	out := make(chan *BulkResult)
+
+	go func() {
+		defer close(indexed)
+		for i := 0; ; i++ {
+			var (
+				item BulkItem
+				ok   bool
+			)
+			select {
+			case item, ok = <-items:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case indexed <- indexedBulkItem{i, item}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range indexed {
+				out <- s.importItem(ctx, it.index, it.item, opts.Upsert)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// notStarted returns the result of an item that was not imported because
+// ctx is done.
+func notStarted(ctx context.Context, index int, item BulkItem) *BulkResult {
+	res := &BulkResult{Index: index, Action: BulkFailed, Err: ctx.Err()}
+	res.ID, res.Type = item.product()
+	return res
+}
+
+// product returns the ID and type of the product of the item.
+func (item BulkItem) product() (string, ProductType) {
+	switch {
+	case item.Loan != nil:
+		return item.Loan.ID, TypeLoan
+	case item.Mortgage != nil:
+		return item.Mortgage.ID, TypeMortgage
+	case item.BankAccount != nil:
+		return item.BankAccount.ID, TypeBankAccount
+	}
+	return "", ""
+}
+
+func (s *ProductsService) importItem(ctx context.Context, index int, item BulkItem, upsert bool) *BulkResult {
+	if ctx.Err() != nil {
+		return notStarted(ctx, index, item)
+	}
+
+	res := &BulkResult{Index: index, Action: BulkCreated}
+	res.ID, res.Type = item.product()
+
+	var err error
+	switch {
+	case item.Loan != nil:
+		err = s.importLoan(ctx, item.Loan, upsert, res)
+	case item.Mortgage != nil:
+		err = s.importMortgage(ctx, item.Mortgage, upsert, res)
+	case item.BankAccount != nil:
+		err = s.importBankAccount(ctx, item.BankAccount, upsert, res)
+	default:
+		err = ErrEmptyBulkItem
+	}
+
+	if err != nil {
+		res.Action, res.Err = BulkFailed, err
+	}
+
+	return res
+}
+
+func (s *ProductsService) importLoan(ctx context.Context, r *NewLoanRequest, upsert bool, res *BulkResult) error {
+	if !upsert || r.ID == "" {
+		return s.NewLoan(ctx, r)
+	}
+
+	existing, err := s.FindLoan(ctx, r.ID)
+	if errors.Is(err, ErrNotFound) {
+		return s.NewLoan(ctx, r)
+	} else if err != nil {
+		return err
+	}
+
+	loan := &Loan{}
+	if err := convertRequest(r, loan); err != nil {
+		return err
+	}
+	loan.Created = existing.Created
+
+	res.Action = BulkUpdated
+	return s.UpdateLoan(ctx, loan)
+}
+
+func (s *ProductsService) importMortgage(ctx context.Context, r *NewMortgageRequest, upsert bool, res *BulkResult) error {
+	if !upsert || r.ID == "" {
+		return s.NewMortgage(ctx, r)
+	}
+
+	existing, err := s.FindMortgage(ctx, r.ID)
+	if errors.Is(err, ErrNotFound) {
+		return s.NewMortgage(ctx, r)
+	} else if err != nil {
+		return err
+	}
+
+	mortgage := &Mortgage{}
+	if err := convertRequest(r, mortgage); err != nil {
+		return err
+	}
+	mortgage.Created = existing.Created
+
+	res.Action = BulkUpdated
+	return s.UpdateMortgage(ctx, mortgage)
+}
+
+func (s *ProductsService) importBankAccount(ctx context.Context, r *NewBankAccountRequest, upsert bool, res *BulkResult) error {
+	if !upsert || r.ID == "" {
+		return s.NewBankAccount(ctx, r)
+	}
+
+	existing, err := s.FindBankAccount(ctx, r.ID)
+	if errors.Is(err, ErrNotFound) {
+		return s.NewBankAccount(ctx, r)
+	} else if err != nil {
+		return err
+	}
+
+	ba := &BankAccount{}
+	if err := convertRequest(r, ba); err != nil {
+		return err
+	}
+	ba.Created = existing.Created
+
+	res.Action = BulkUpdated
+	return s.UpdateBankAccount(ctx, ba)
+}
+
+// convertRequest copies the fields of a create request to the product
+// they share their JSON representation with.
+func convertRequest(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
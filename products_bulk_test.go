@@ -0,0 +1,137 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportProducts(t *testing.T) {
+	mu := sync.Mutex{}
+	seen := map[string]int{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/mortgages/existing":
+			w.Write([]byte(`{"id":"existing","created":"2023-01-01T00:00:00Z"}`))
+		case "GET /v2/mortgages/new", "GET /v1/loans/l1":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST /v1/bankaccounts":
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+
+	report := c.Products().ImportProducts(context.Background(), []BulkItem{
+		BulkMortgage(&NewMortgageRequest{ID: "existing"}),
+		BulkMortgage(&NewMortgageRequest{ID: "new"}),
+		BulkLoan(&NewLoanRequest{ID: "l1"}),
+		BulkBankAccount(&NewBankAccountRequest{ID: "b1"}),
+		{},
+	}, BulkOptions{Concurrency: 2, Upsert: true})
+
+	assert.Len(t, report.Results, 5)
+	assert.Equal(t, BulkUpdated, report.Results[0].Action)
+	assert.Equal(t, BulkCreated, report.Results[1].Action)
+	assert.Equal(t, BulkCreated, report.Results[2].Action)
+	assert.Equal(t, TypeLoan, report.Results[2].Type)
+	assert.Equal(t, BulkFailed, report.Results[3].Action)
+	assert.ErrorIs(t, report.Results[4].Err, ErrEmptyBulkItem)
+	assert.Len(t, report.Failed(), 2)
+	assert.Error(t, report.Err())
+
+	assert.Equal(t, 1, seen["PUT /v2/mortgages/existing"])
+	assert.Equal(t, 1, seen["POST /v2/mortgages"])
+}
+
+// cancelAfter cancels once the request to path has completed.
+type cancelAfter struct {
+	path   string
+	cancel context.CancelFunc
+}
+
+func (c *cancelAfter) RoundTrip(r *http.Request) (*http.Response, error) {
+	res, err := http.DefaultTransport.RoundTrip(r)
+	if r.URL.Path == c.path {
+		c.cancel()
+	}
+	return res, err
+}
+
+func TestImportProductsCancel(t *testing.T) {
+	mu := sync.Mutex{}
+	created := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		created++
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hc := &http.Client{Transport: &cancelAfter{path: "/v1/loans", cancel: cancel}}
+	c, _ := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")), WithHTTPClient(hc))
+
+	report := c.Products().ImportProducts(ctx, []BulkItem{
+		BulkLoan(&NewLoanRequest{ID: "l1"}),
+		BulkMortgage(&NewMortgageRequest{ID: "m1"}),
+		BulkBankAccount(&NewBankAccountRequest{ID: "b1"}),
+	}, BulkOptions{Concurrency: 1})
+
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, BulkCreated, report.Results[0].Action, "a product created before cancelling is not reported as failed")
+	assert.NoError(t, report.Results[0].Err)
+	for _, res := range report.Results[1:] {
+		assert.Equal(t, BulkFailed, res.Action)
+		assert.ErrorIs(t, res.Err, context.Canceled)
+		assert.NotEmpty(t, res.ID)
+	}
+	assert.Equal(t, 1, created)
+}
+
+func TestImportProductsStreamCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c, _ := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	items := make(chan BulkItem)
+	out := c.Products().ImportProductsStream(ctx, items, BulkOptions{Concurrency: 2})
+
+	// The items channel is never closed, cancelling must still close the
+	// result channel once the started item completes.
+	items <- BulkLoan(&NewLoanRequest{ID: "l1"})
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range out {
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the result channel was not closed after cancelling")
+	}
+
+	report := c.Products().ImportProducts(ctx, []BulkItem{BulkLoan(&NewLoanRequest{ID: "l2"})}, BulkOptions{})
+	if assert.Len(t, report.Results, 1) {
+		assert.ErrorIs(t, report.Results[0].Err, context.Canceled)
+	}
+}
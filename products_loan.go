@@ -5,63 +5,61 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	querystring "github.com/google/go-querystring/query"
-	"go.opencensus.io/trace"
 )
 
 type (
 	NewLoanRequest struct {
-		ID                string                 `json:"id"`
-		Issuer            string                 `json:"issuer"`
-		Name              string                 `json:"name"`
-		Description       string                 `json:"description"`
-		URLApply          string                 `json:"url_apply"`
-		URLLogo           string                 `json:"url_logo"`
-		HighlightedPoints []string               `json:"highlighted_points"`
-		TechnicalPoints   []string               `json:"technical_points"`
-		InterestRate      Rate                   `json:"interest_rate"`
-		MonthlyFee        Fee                    `json:"monthly_fee"`
-		SetupFee          Fee                    `json:"setup_fee"`
-		MinimumLoan       Money                  `json:"minimum_loan"`
-		MaximumLoan       Money                  `json:"maximum_loan"`
-		MinimumTerm       Months                 `json:"minimum_term"`
-		MaximumTerm       Months                 `json:"maximum_term"`
-		GuarantorAllowed  bool                   `json:"guarantor_allowed"`
-		GuarantorCriteria []string               `json:"guarantor_criteria"`
-		IsConsumer        bool                   `json:"is_consumer"`
-		IsCommercial      bool                   `json:"is_commercial"`
-		BrokerOnly        bool                   `json:"broker_only"`
-		Active            bool                   `json:"active"`
-		Meta              map[string]interface{} `json:"metadata"`
+		ID                string   `json:"id"`
+		Issuer            string   `json:"issuer"`
+		Name              string   `json:"name"`
+		Description       string   `json:"description"`
+		URLApply          string   `json:"url_apply"`
+		URLLogo           string   `json:"url_logo"`
+		HighlightedPoints []string `json:"highlighted_points"`
+		TechnicalPoints   []string `json:"technical_points"`
+		InterestRate      Rate     `json:"interest_rate"`
+		MonthlyFee        Fee      `json:"monthly_fee"`
+		SetupFee          Fee      `json:"setup_fee"`
+		MinimumLoan       Money    `json:"minimum_loan"`
+		MaximumLoan       Money    `json:"maximum_loan"`
+		MinimumTerm       Months   `json:"minimum_term"`
+		MaximumTerm       Months   `json:"maximum_term"`
+		GuarantorAllowed  bool     `json:"guarantor_allowed"`
+		GuarantorCriteria []string `json:"guarantor_criteria"`
+		IsConsumer        bool     `json:"is_consumer"`
+		IsCommercial      bool     `json:"is_commercial"`
+		BrokerOnly        bool     `json:"broker_only"`
+		Active            bool     `json:"active"`
+		Meta              Metadata `json:"metadata"`
 	}
 
 	Loan struct {
-		ID                string                 `json:"id"`
-		Issuer            string                 `json:"issuer"`
-		Name              string                 `json:"name"`
-		Description       string                 `json:"description"`
-		URLApply          string                 `json:"url_apply"`
-		URLLogo           string                 `json:"url_logo"`
-		HighlightedPoints []string               `json:"highlighted_points"`
-		TechnicalPoints   []string               `json:"technical_points"`
-		InterestRate      Rate                   `json:"interest_rate"`
-		MonthlyFee        Fee                    `json:"monthly_fee"`
-		SetupFee          Fee                    `json:"setup_fee"`
-		MinimumLoan       Money                  `json:"minimum_loan"`
-		MaximumLoan       Money                  `json:"maximum_loan"`
-		MinimumTerm       Months                 `json:"minimum_term"`
-		MaximumTerm       Months                 `json:"maximum_term"`
-		GuarantorAllowed  bool                   `json:"guarantor_allowed"`
-		GuarantorCriteria []string               `json:"guarantor_criteria"`
-		IsConsumer        bool                   `json:"is_consumer"`
-		IsCommercial      bool                   `json:"is_commercial"`
-		BrokerOnly        bool                   `json:"broker_only"`
-		Active            bool                   `json:"active"`
-		Meta              map[string]interface{} `json:"metadata"`
-		Created           time.Time              `json:"created"`
+		ID                string    `json:"id"`
+		Issuer            string    `json:"issuer"`
+		Name              string    `json:"name"`
+		Description       string    `json:"description"`
+		URLApply          string    `json:"url_apply"`
+		URLLogo           string    `json:"url_logo"`
+		HighlightedPoints []string  `json:"highlighted_points"`
+		TechnicalPoints   []string  `json:"technical_points"`
+		InterestRate      Rate      `json:"interest_rate"`
+		MonthlyFee        Fee       `json:"monthly_fee"`
+		SetupFee          Fee       `json:"setup_fee"`
+		MinimumLoan       Money     `json:"minimum_loan"`
+		MaximumLoan       Money     `json:"maximum_loan"`
+		MinimumTerm       Months    `json:"minimum_term"`
+		MaximumTerm       Months    `json:"maximum_term"`
+		GuarantorAllowed  bool      `json:"guarantor_allowed"`
+		GuarantorCriteria []string  `json:"guarantor_criteria"`
+		IsConsumer        bool      `json:"is_consumer"`
+		IsCommercial      bool      `json:"is_commercial"`
+		BrokerOnly        bool      `json:"broker_only"`
+		Active            bool      `json:"active"`
+		Meta              Metadata  `json:"metadata"`
+		Created           time.Time `json:"created"`
 	}
 
 	ListLoansResponse struct {
@@ -70,83 +68,115 @@ type (
 )
 
 func (s *ProductsService) FindLoan(ctx context.Context, id string) (*Loan, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.FindLoan")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.FindLoan")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, id)
 
-	req, err := s.c.newRequest("GET", fmt.Sprintf("/v1/loans/%s", id), nil)
+	req, err := s.c.newRequest(ctx, "GET", "/v1/loans/{id}", nil, id)
 
 	if err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return nil, ErrUnreachable
 	}
 	defer res.Body.Close()
 
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return nil, err
+	}
+
 	prd := &Loan{}
-	return prd, unmarshalResponse(res, prd)
+	return prd, s.c.decode(res, prd)
 }
 
 func (s *ProductsService) UpdateLoan(ctx context.Context, loan *Loan) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.UpdateLoan")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.UpdateLoan")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, loan.ID)
 
 	if len(loan.ID) == 0 {
 		return errors.New("can only update an existing loan")
 	}
 
+	if err := s.c.validateProduct(loan, loan.Meta); err != nil {
+		return err
+	}
+
 	rb, _ := json.Marshal(loan)
-	req, err := s.c.newRequest("PUT", fmt.Sprintf("/v1/loans/%s", loan.ID), bytes.NewReader(rb))
+	req, err := s.c.newRequest(ctx, "PUT", "/v1/loans/{id}", bytes.NewReader(rb), loan.ID)
 
 	if err != nil {
+		s.c.logError(err)
 		return err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
+		s.c.logError(err)
 		return ErrUnreachable
 	}
 	defer res.Body.Close()
 
-	return statusCodeToError(res.StatusCode)
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return err
+	}
+
+	return nil
 }
 
 func (s *ProductsService) NewLoan(ctx context.Context, opts *NewLoanRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.NewLoan")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.NewLoan")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, opts.ID)
+
+	if err := s.c.validateProduct(opts, opts.Meta); err != nil {
+		return err
+	}
 
 	rb, _ := json.Marshal(opts)
-	req, err := s.c.newRequest("POST", "/v1/loans", bytes.NewReader(rb))
+	req, err := s.c.newRequest(ctx, "POST", "/v1/loans", bytes.NewReader(rb))
 
 	if err != nil {
+		s.c.logError(err)
 		return err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
+		s.c.logError(err)
 		return ErrUnreachable
 	}
 	defer res.Body.Close()
 
-	return statusCodeToError(res.StatusCode)
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return err
+	}
+
+	return nil
 }
 
 func (s *ProductsService) ListLoans(ctx context.Context, filters *ProductFilters) (*ListLoansResponse, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.ListLoans")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.ListLoans")
 	defer span.End()
 
 	obj := &ListLoansResponse{}
 	qs, _ := querystring.Values(filters)
 
-	req, err := s.c.newRequest("GET", "/v1/loans?"+qs.Encode(), nil)
+	req, err := s.c.newRequest(ctx, "GET", "/v1/loans?"+qs.Encode(), nil)
 	if err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return nil, ErrUnreachable
@@ -154,8 +184,15 @@ func (s *ProductsService) ListLoans(ctx context.Context, filters *ProductFilters
 	defer res.Body.Close()
 
 	if err = statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	return obj, unmarshalResponse(res, obj)
+	if err := s.c.decode(res, obj); err != nil {
+		return obj, err
+	}
+	if filters != nil {
+		obj.Data = filterMetadata(obj.Data, filters.Metadata, (*Loan).GetMeta)
+	}
+	return obj, nil
 }
@@ -0,0 +1,94 @@
+package capis
+
+import (
+	"fmt"
+	"math"
+)
+
+// LoanQuote is the cost of a loan for an amount and term.
+type LoanQuote struct {
+	// MonthlyRepayment includes the monthly fee.
+	MonthlyRepayment Money `json:"monthly_repayment"`
+	MonthlyFee       Money `json:"monthly_fee"`
+	SetupFee         Money `json:"setup_fee"`
+	// TotalRepayable is all of the repayments and fees.
+	TotalRepayable Money `json:"total_repayable"`
+	TotalInterest  Money `json:"total_interest"`
+	// APR is the annual percentage rate of charge rounded to one decimal
+	// place, see CONC App 1.1 of the FCA handbook.
+	APR float64 `json:"apr"`
+	// Schedule is the interest and capital repaid each month, the payments
+	// do not include the monthly fee.
+	Schedule []*Payment `json:"schedule"`
+}
+
+// Quote returns the repayments and cost of the loan for the amount over
+// the term, both must be within the range of the loan.
+func (l *Loan) Quote(amount Money, term Months) (*LoanQuote, error) {
+	if err := checkAmount(amount, l.MinimumLoan, l.MaximumLoan); err != nil {
+		return nil, err
+	}
+	if err := checkTerm(term, l.MinimumTerm, l.MaximumTerm); err != nil {
+		return nil, err
+	}
+
+	setup, err := l.SetupFee.Cost(amount)
+	if err != nil {
+		return nil, fmt.Errorf("setup fee %w", err)
+	}
+	monthly, err := l.MonthlyFee.Cost(amount)
+	if err != nil {
+		return nil, fmt.Errorf("monthly fee %w", err)
+	}
+
+	q := &LoanQuote{
+		MonthlyFee: NewMoney(amount.Currency, monthly.Amount, ""),
+		SetupFee:   NewMoney(amount.Currency, setup.Amount, ""),
+		Schedule:   make([]*Payment, 0, term.Value),
+	}
+
+	payment := monthlyPayment(amount.Amount, l.InterestRate.Value, term.Value, RepaymentCapital)
+	amortise(&q.Schedule, amount.Currency, amount.Amount, l.InterestRate.Value, payment, term.Value, true)
+
+	total, interest := setup.Amount, int64(0)
+	for _, p := range q.Schedule {
+		total += p.Payment.Amount + monthly.Amount
+		interest += p.Interest.Amount
+	}
+
+	q.MonthlyRepayment = NewMoney(amount.Currency, payment+monthly.Amount, "")
+	q.TotalRepayable = NewMoney(amount.Currency, total, "")
+	q.TotalInterest = NewMoney(amount.Currency, interest, "")
+	q.APR = loanAPR(amount.Amount, setup.Amount, monthly.Amount, q.Schedule)
+
+	return q, nil
+}
+
+// loanAPR solves the CONC App 1.1 equation for the annual rate X where the
+// amount advanced equals the setup fee paid at the start plus each monthly
+// repayment discounted by (1+X)^(month/12).
+func loanAPR(advance, setup, monthly int64, schedule []*Payment) float64 {
+	charges := func(x float64) float64 {
+		sum := float64(setup)
+		for _, p := range schedule {
+			sum += float64(p.Payment.Amount+monthly) / math.Pow(1+x, float64(p.Month)/12)
+		}
+		return sum
+	}
+
+	lo, hi := 0.0, 1.0
+	for charges(hi) > float64(advance) && hi < 1e6 {
+		hi *= 2
+	}
+
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		if charges(mid) > float64(advance) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return math.Round(lo*1000) / 10
+}
@@ -0,0 +1,42 @@
+package capis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoanQuote(t *testing.T) {
+	l := &Loan{
+		InterestRate: NewRate(6.9, ""),
+		MinimumLoan:  NewMoney("GBP", 100000, ""),
+		MaximumLoan:  NewMoney("GBP", 2500000, ""),
+		MinimumTerm:  NewMonths(12, ""),
+		MaximumTerm:  NewMonths(60, ""),
+	}
+
+	q, err := l.Quote(NewMoney("GBP", 1000000, ""), NewMonths(36, ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, 7.1, q.APR)
+	assert.Len(t, q.Schedule, 36)
+	assert.Equal(t, int64(0), q.Schedule[35].Balance.Amount)
+	assert.Equal(t, q.TotalRepayable.Amount-1000000, q.TotalInterest.Amount)
+
+	l.SetupFee = NewFixedFee(&Money{Currency: "GBP", Amount: 20000}, "")
+	l.MonthlyFee = NewFixedFee(&Money{Currency: "GBP", Amount: 500}, "")
+
+	withFees, err := l.Quote(NewMoney("GBP", 1000000, ""), NewMonths(36, ""))
+	require.NoError(t, err)
+
+	assert.Greater(t, withFees.APR, q.APR)
+	assert.Equal(t, q.MonthlyRepayment.Amount+500, withFees.MonthlyRepayment.Amount)
+	assert.Equal(t, q.TotalRepayable.Amount+20000+36*500, withFees.TotalRepayable.Amount)
+
+	_, err = l.Quote(NewMoney("GBP", 1000000, ""), NewMonths(72, ""))
+	assert.ErrorIs(t, err, ErrTermOutOfRange)
+
+	_, err = l.Quote(NewMoney("GBP", 5000000, ""), NewMonths(36, ""))
+	assert.ErrorIs(t, err, ErrAmountOutOfRange)
+}
@@ -5,71 +5,71 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	querystring "github.com/google/go-querystring/query"
-	"go.opencensus.io/trace"
 )
 
 type (
 	NewMortgageRequest struct {
-		ID                       string                 `json:"id"`
-		Issuer                   string                 `json:"issuer"`
-		Name                     string                 `json:"name"`
-		Description              string                 `json:"description"`
-		URLApply                 string                 `json:"url_apply"`
-		URLLogo                  string                 `json:"url_logo"`
-		HighlightedPoints        []string               `json:"highlighted_points"`
-		TechnicalPoints          []string               `json:"technical_points"`
-		Labels                   []string               `json:"labels"`
-		Type                     string                 `json:"type"`
-		OfferInterestRate        RatePeriod             `json:"offer_interest_rate"`
-		OfferInterestRateType    string                 `json:"offer_interest_rate_type"`
-		StandardInterestRate     Rate                   `json:"standard_interest_rate"`
-		StandardInterestRateType string                 `json:"standard_interest_rate_type"`
-		LoanToValue              Rate                   `json:"loan_to_value"`
-		Fee                      Fee                    `json:"fee"`
-		MinimumLoan              Money                  `json:"minimum_loan"`
-		MaximumLoan              Money                  `json:"maximum_loan"`
-		MinimumTerm              Months                 `json:"minimum_term"`
-		MaximumTerm              Months                 `json:"maximum_term"`
-		EarlyRedemptionCharge    Fee                    `json:"early_redemption_charge"`
-		IsConsumer               bool                   `json:"is_consumer"`
-		IsCommercial             bool                   `json:"is_commercial"`
-		BrokerOnly               bool                   `json:"broker_only"`
-		Active                   bool                   `json:"active"`
-		Meta                     map[string]interface{} `json:"metadata"`
+		ID                string   `json:"id"`
+		Issuer            string   `json:"issuer"`
+		Name              string   `json:"name"`
+		Description       string   `json:"description"`
+		URLApply          string   `json:"url_apply"`
+		URLLogo           string   `json:"url_logo"`
+		HighlightedPoints []string `json:"highlighted_points"`
+		TechnicalPoints   []string `json:"technical_points"`
+		// Labels are free-form, capis does not define a fixed set.
+		Labels                   []string         `json:"labels"`
+		Type                     MortgageRateType `json:"type"`
+		OfferInterestRate        RatePeriod       `json:"offer_interest_rate"`
+		OfferInterestRateType    MortgageRateType `json:"offer_interest_rate_type"`
+		StandardInterestRate     Rate             `json:"standard_interest_rate"`
+		StandardInterestRateType MortgageRateType `json:"standard_interest_rate_type"`
+		LoanToValue              Rate             `json:"loan_to_value"`
+		Fee                      Fee              `json:"fee"`
+		MinimumLoan              Money            `json:"minimum_loan"`
+		MaximumLoan              Money            `json:"maximum_loan"`
+		MinimumTerm              Months           `json:"minimum_term"`
+		MaximumTerm              Months           `json:"maximum_term"`
+		EarlyRedemptionCharge    Fee              `json:"early_redemption_charge"`
+		IsConsumer               bool             `json:"is_consumer"`
+		IsCommercial             bool             `json:"is_commercial"`
+		BrokerOnly               bool             `json:"broker_only"`
+		Active                   bool             `json:"active"`
+		Meta                     Metadata         `json:"metadata"`
 	}
 
 	Mortgage struct {
-		ID                       string                 `json:"id"`
-		Issuer                   string                 `json:"issuer"`
-		Name                     string                 `json:"name"`
-		Description              string                 `json:"description"`
-		URLApply                 string                 `json:"url_apply"`
-		URLLogo                  string                 `json:"url_logo"`
-		HighlightedPoints        []string               `json:"highlighted_points"`
-		TechnicalPoints          []string               `json:"technical_points"`
-		Labels                   []string               `json:"labels"`
-		Type                     string                 `json:"type"`
-		OfferInterestRate        RatePeriod             `json:"offer_interest_rate"`
-		OfferInterestRateType    string                 `json:"offer_interest_rate_type"`
-		StandardInterestRate     Rate                   `json:"standard_interest_rate"`
-		StandardInterestRateType string                 `json:"standard_interest_rate_type"`
-		LoanToValue              Rate                   `json:"loan_to_value"`
-		Fee                      Fee                    `json:"fee"`
-		MinimumLoan              Money                  `json:"minimum_loan"`
-		MaximumLoan              Money                  `json:"maximum_loan"`
-		MinimumTerm              Months                 `json:"minimum_term"`
-		MaximumTerm              Months                 `json:"maximum_term"`
-		EarlyRedemptionCharge    Fee                    `json:"early_redemption_charge"`
-		IsConsumer               bool                   `json:"is_consumer"`
-		IsCommercial             bool                   `json:"is_commercial"`
-		BrokerOnly               bool                   `json:"broker_only"`
-		Active                   bool                   `json:"active"`
-		Meta                     map[string]interface{} `json:"metadata"`
-		Created                  time.Time              `json:"created"`
+		ID                string   `json:"id"`
+		Issuer            string   `json:"issuer"`
+		Name              string   `json:"name"`
+		Description       string   `json:"description"`
+		URLApply          string   `json:"url_apply"`
+		URLLogo           string   `json:"url_logo"`
+		HighlightedPoints []string `json:"highlighted_points"`
+		TechnicalPoints   []string `json:"technical_points"`
+		// Labels are free-form, capis does not define a fixed set.
+		Labels                   []string         `json:"labels"`
+		Type                     MortgageRateType `json:"type"`
+		OfferInterestRate        RatePeriod       `json:"offer_interest_rate"`
+		OfferInterestRateType    MortgageRateType `json:"offer_interest_rate_type"`
+		StandardInterestRate     Rate             `json:"standard_interest_rate"`
+		StandardInterestRateType MortgageRateType `json:"standard_interest_rate_type"`
+		LoanToValue              Rate             `json:"loan_to_value"`
+		Fee                      Fee              `json:"fee"`
+		MinimumLoan              Money            `json:"minimum_loan"`
+		MaximumLoan              Money            `json:"maximum_loan"`
+		MinimumTerm              Months           `json:"minimum_term"`
+		MaximumTerm              Months           `json:"maximum_term"`
+		EarlyRedemptionCharge    Fee              `json:"early_redemption_charge"`
+		IsConsumer               bool             `json:"is_consumer"`
+		IsCommercial             bool             `json:"is_commercial"`
+		BrokerOnly               bool             `json:"broker_only"`
+		Active                   bool             `json:"active"`
+		Meta                     Metadata         `json:"metadata"`
+		Created                  time.Time        `json:"created"`
 	}
 
 	ListMortgagesResponse struct {
@@ -78,87 +78,120 @@ type (
 )
 
 func (s *ProductsService) FindMortgage(ctx context.Context, id string) (*Mortgage, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.FindMortgage")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.FindMortgage")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, id)
 
-	req, err := s.c.newRequest("GET", fmt.Sprintf("/v2/mortgages/%s", id), nil)
+	req, err := s.c.newRequest(ctx, "GET", "/v2/mortgages/{id}", nil, id)
 
 	if err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return nil, ErrUnreachable
 	}
 	defer res.Body.Close()
 
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return nil, err
+	}
+
 	prd := &Mortgage{}
-	return prd, unmarshalResponse(res, prd)
+	return prd, s.c.decode(res, prd)
 }
 
 func (s *ProductsService) UpdateMortgage(ctx context.Context, mortgage *Mortgage) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.UpdateMortgage")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.UpdateMortgage")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, mortgage.ID)
 
 	if len(mortgage.ID) == 0 {
 		return errors.New("can only update an existing mortgage")
 	}
 
+	if err := s.c.validateProduct(mortgage, mortgage.Meta); err != nil {
+		return err
+	}
+
 	rb, _ := json.Marshal(mortgage)
-	req, err := s.c.newRequest("PUT", fmt.Sprintf("/v2/mortgages/%s", mortgage.ID), bytes.NewReader(rb))
+	req, err := s.c.newRequest(ctx, "PUT", "/v2/mortgages/{id}", bytes.NewReader(rb), mortgage.ID)
 
 	if err != nil {
+		s.c.logError(err)
 		return err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
+		s.c.logError(err)
 		return ErrUnreachable
 	}
 	defer res.Body.Close()
 
-	return statusCodeToError(res.StatusCode)
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return err
+	}
+
+	return nil
 }
 
 func (s *ProductsService) NewMortgage(ctx context.Context, opts *NewMortgageRequest) error {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.NewMortgage")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.NewMortgage")
 	defer span.End()
+	span.SetAttribute(AttributeProductID, opts.ID)
+
+	if err := s.c.validateProduct(opts, opts.Meta); err != nil {
+		return err
+	}
 
 	rb, _ := json.Marshal(opts)
-	req, err := s.c.newRequest("POST", "/v2/mortgages", bytes.NewReader(rb))
+	req, err := s.c.newRequest(ctx, "POST", "/v2/mortgages", bytes.NewReader(rb))
 
 	if err != nil {
+		s.c.logError(err)
 		return err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
+		s.c.logError(err)
 		return ErrUnreachable
 	}
 	defer res.Body.Close()
 
-	return statusCodeToError(res.StatusCode)
+	if err := statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
+		return err
+	}
+
+	return nil
 }
 
 type MortgageProductFilters struct {
-	ID []string `url:"id,comma"`
+	ID       []string          `url:"id,comma"`
+	Metadata MetadataSelectors `url:"-"`
 }
 
 func (s *ProductsService) ListMortgages(ctx context.Context, filters *MortgageProductFilters) (*ListMortgagesResponse, error) {
-	ctx, span := trace.StartSpan(ctx, "lwebco.de/go-capis/ProductsService.ListMortgages")
+	ctx, span := s.c.startSpan(ctx, "lwebco.de/go-capis/ProductsService.ListMortgages")
 	defer span.End()
 
 	obj := &ListMortgagesResponse{}
 	qs, _ := querystring.Values(filters)
 
-	req, err := s.c.newRequest("GET", "/v2/mortgages?"+qs.Encode(), nil)
+	req, err := s.c.newRequest(ctx, "GET", "/v2/mortgages?"+qs.Encode(), nil)
 	if err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	res, err := s.c.Do(req.WithContext(ctx))
+	res, err := s.c.Do(req)
 	if err != nil {
 		s.c.logError(err)
 		return nil, ErrUnreachable
@@ -166,8 +199,15 @@ func (s *ProductsService) ListMortgages(ctx context.Context, filters *MortgagePr
 	defer res.Body.Close()
 
 	if err = statusCodeToError(res.StatusCode); err != nil {
+		s.c.logError(err)
 		return nil, err
 	}
 
-	return obj, unmarshalResponse(res, obj)
+	if err := s.c.decode(res, obj); err != nil {
+		return obj, err
+	}
+	if filters != nil {
+		obj.Data = filterMetadata(obj.Data, filters.Metadata, (*Mortgage).GetMeta)
+	}
+	return obj, nil
 }
@@ -0,0 +1,233 @@
+package capis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RepaymentMethod is how the capital of a mortgage is repaid.
+type RepaymentMethod int
+
+const (
+	// RepaymentCapital repays the interest and capital each month.
+	RepaymentCapital RepaymentMethod = iota
+	// RepaymentInterestOnly repays the interest each month and the capital
+	// at the end of the term.
+	RepaymentInterestOnly
+)
+
+var (
+	// ErrAmountOutOfRange is returned when the amount is outside of the
+	// minimum and maximum of the product.
+	ErrAmountOutOfRange = errors.New("amount is out of range for the product")
+	// ErrTermOutOfRange is returned when the term is outside of the minimum
+	// and maximum of the product.
+	ErrTermOutOfRange = errors.New("term is out of range for the product")
+	// ErrLoanToValueExceeded is returned when the loan is more than the
+	// maximum loan to value of the mortgage.
+	ErrLoanToValueExceeded = errors.New("loan to value exceeded")
+)
+
+type (
+	// MortgageQuoteRequest is the borrowing to quote a mortgage for.
+	MortgageQuoteRequest struct {
+		LoanAmount Money
+		// PropertyValue is used to check the loan to value when set.
+		PropertyValue Money
+		Term          Months
+		Method        RepaymentMethod
+	}
+
+	// Payment is a single monthly payment of a repayment schedule.
+	Payment struct {
+		// Month is the number of the payment starting at 1.
+		Month     int64 `json:"month"`
+		Payment   Money `json:"payment"`
+		Interest  Money `json:"interest"`
+		Principal Money `json:"principal"`
+		// Balance is the amount outstanding after the payment.
+		Balance Money `json:"balance"`
+	}
+
+	// MortgageQuote is the cost of a mortgage for the borrowing.
+	MortgageQuote struct {
+		LoanToValue float64
+		// InitialPeriod is the number of months at the offer rate.
+		InitialPeriod  int64
+		InitialPayment Money
+		// ReversionPayment is the monthly payment at the standard rate
+		// once the offer period ends.
+		ReversionPayment Money
+		Fees             Money
+		// OfferPeriodCost is the payments and fees of the offer period.
+		OfferPeriodCost Money
+		// TotalCost is the payments and fees of the full term.
+		TotalCost Money
+		// TrueCost is the offer period cost less the capital repaid during
+		// the offer period, it is used to compare mortgages.
+		TrueCost Money
+		Schedule []*Payment
+	}
+)
+
+// Quote returns the monthly payments and cost of the mortgage for the
+// borrowing, the offer rate applies for the offer period and the standard
+// rate for the rest of the term.
+func (m *Mortgage) Quote(req MortgageQuoteRequest) (*MortgageQuote, error) {
+	loan := req.LoanAmount
+
+	if err := checkAmount(loan, m.MinimumLoan, m.MaximumLoan); err != nil {
+		return nil, err
+	}
+	if err := checkTerm(req.Term, m.MinimumTerm, m.MaximumTerm); err != nil {
+		return nil, err
+	}
+
+	q := &MortgageQuote{}
+
+	if req.PropertyValue.Amount > 0 {
+		if err := loan.sameCurrency(req.PropertyValue); err != nil {
+			return nil, fmt.Errorf("property value %w", err)
+		}
+
+		q.LoanToValue = float64(loan.Amount) / float64(req.PropertyValue.Amount) * 100
+		if m.LoanToValue.Value > 0 && q.LoanToValue > m.LoanToValue.Value {
+			return nil, fmt.Errorf("%w: %.2f%% is more than %.2f%%", ErrLoanToValueExceeded, q.LoanToValue, m.LoanToValue.Value)
+		}
+	}
+
+	fees, err := m.Fee.Cost(loan)
+	if err != nil {
+		return nil, fmt.Errorf("fee %w", err)
+	}
+	q.Fees = NewMoney(loan.Currency, fees.Amount, "")
+
+	term := req.Term.Value
+	q.InitialPeriod = m.OfferInterestRate.Period.Value
+	if q.InitialPeriod <= 0 || q.InitialPeriod > term {
+		q.InitialPeriod = term
+	}
+
+	q.Schedule = make([]*Payment, 0, term)
+	balance := loan.Amount
+
+	periods := []struct {
+		rate   float64
+		months int64
+	}{
+		{m.OfferInterestRate.Value, q.InitialPeriod},
+		{m.StandardInterestRate.Value, term - q.InitialPeriod},
+	}
+
+	for i, p := range periods {
+		if p.months == 0 {
+			continue
+		}
+
+		remaining := term - int64(len(q.Schedule))
+		payment := monthlyPayment(balance, p.rate, remaining, req.Method)
+
+		if i == 0 {
+			q.InitialPayment = NewMoney(loan.Currency, payment, "")
+		} else {
+			q.ReversionPayment = NewMoney(loan.Currency, payment, "")
+		}
+
+		balance = amortise(&q.Schedule, loan.Currency, balance, p.rate, payment, p.months, remaining == p.months)
+	}
+
+	var offerPayments, totalPayments, offerPrincipal int64
+	for _, p := range q.Schedule {
+		totalPayments += p.Payment.Amount
+		if p.Month <= q.InitialPeriod {
+			offerPayments += p.Payment.Amount
+			offerPrincipal += p.Principal.Amount
+		}
+	}
+
+	q.OfferPeriodCost = NewMoney(loan.Currency, offerPayments+fees.Amount, "")
+	q.TotalCost = NewMoney(loan.Currency, totalPayments+fees.Amount, "")
+	q.TrueCost = NewMoney(loan.Currency, offerPayments+fees.Amount-offerPrincipal, "")
+
+	return q, nil
+}
+
+// monthlyPayment returns the payment in minor units that repays the
+// balance over the months at the annual rate.
+func monthlyPayment(balance int64, rate float64, months int64, method RepaymentMethod) int64 {
+	r := rate / 100 / 12
+
+	if method == RepaymentInterestOnly {
+		return int64(math.Round(float64(balance) * r))
+	}
+	if r == 0 {
+		return int64(math.Ceil(float64(balance) / float64(months)))
+	}
+
+	return int64(math.Round(float64(balance) * r / (1 - math.Pow(1+r, -float64(months)))))
+}
+
+// amortise appends the payments for the months to the schedule and
+// returns the balance left, the final payment clears the balance when last
+// is set.
+func amortise(schedule *[]*Payment, currency string, balance int64, rate float64, payment int64, months int64, last bool) int64 {
+	r := rate / 100 / 12
+
+	for i := int64(0); i < months; i++ {
+		interest := int64(math.Round(float64(balance) * r))
+		principal := payment - interest
+
+		if principal > balance || (last && i == months-1) {
+			principal = balance
+		}
+		balance -= principal
+
+		*schedule = append(*schedule, &Payment{
+			Month:     int64(len(*schedule)) + 1,
+			Payment:   NewMoney(currency, interest+principal, ""),
+			Interest:  NewMoney(currency, interest, ""),
+			Principal: NewMoney(currency, principal, ""),
+			Balance:   NewMoney(currency, balance, ""),
+		})
+	}
+
+	return balance
+}
+
+// checkAmount checks the amount is within the product minimum and maximum,
+// a zero minimum or maximum is not checked.
+func checkAmount(amount, min, max Money) error {
+	if amount.Amount <= 0 {
+		return fmt.Errorf("%w: %s must be more than zero", ErrAmountOutOfRange, amount)
+	}
+	if min.Amount > 0 {
+		if c, err := amount.Cmp(min); err != nil {
+			return err
+		} else if c < 0 {
+			return fmt.Errorf("%w: %s is less than %s", ErrAmountOutOfRange, amount, min)
+		}
+	}
+	if max.Amount > 0 {
+		if c, err := amount.Cmp(max); err != nil {
+			return err
+		} else if c > 0 {
+			return fmt.Errorf("%w: %s is more than %s", ErrAmountOutOfRange, amount, max)
+		}
+	}
+	return nil
+}
+
+// checkTerm checks the term is within the product minimum and maximum, a
+// zero minimum or maximum is not checked.
+func checkTerm(term, min, max Months) error {
+	switch {
+	case term.Value <= 0:
+		return fmt.Errorf("%w: %d months must be more than zero", ErrTermOutOfRange, term.Value)
+	case min.Value > 0 && term.Value < min.Value:
+		return fmt.Errorf("%w: %d months is less than %d", ErrTermOutOfRange, term.Value, min.Value)
+	case max.Value > 0 && term.Value > max.Value:
+		return fmt.Errorf("%w: %d months is more than %d", ErrTermOutOfRange, term.Value, max.Value)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package capis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMortgageQuote(t *testing.T) {
+	m := &Mortgage{
+		OfferInterestRate:    NewRatePeriod(5, "", NewMonths(24, "")),
+		StandardInterestRate: NewRate(7, ""),
+		LoanToValue:          NewRate(80, ""),
+		Fee:                  NewFixedFee(&Money{Currency: "GBP", Amount: 99900}, ""),
+		MinimumLoan:          NewMoney("GBP", 2500000, ""),
+		MaximumLoan:          NewMoney("GBP", 100000000, ""),
+		MaximumTerm:          NewMonths(420, ""),
+	}
+
+	q, err := m.Quote(MortgageQuoteRequest{
+		LoanAmount:    NewMoney("GBP", 20000000, ""),
+		PropertyValue: NewMoney("GBP", 25000000, ""),
+		Term:          NewMonths(300, ""),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(80), q.LoanToValue)
+	assert.Equal(t, int64(24), q.InitialPeriod)
+	assert.Equal(t, int64(116918), q.InitialPayment.Amount)
+	assert.Greater(t, q.ReversionPayment.Amount, q.InitialPayment.Amount)
+	assert.Len(t, q.Schedule, 300)
+	assert.Equal(t, int64(0), q.Schedule[299].Balance.Amount)
+
+	var principal int64
+	for _, p := range q.Schedule {
+		principal += p.Principal.Amount
+	}
+	assert.Equal(t, int64(20000000), principal)
+	assert.Equal(t, int64(116918*24+99900), q.OfferPeriodCost.Amount)
+	assert.Equal(t, q.OfferPeriodCost.Amount-(20000000-q.Schedule[23].Balance.Amount), q.TrueCost.Amount)
+
+	io, err := m.Quote(MortgageQuoteRequest{
+		LoanAmount: NewMoney("GBP", 20000000, ""),
+		Term:       NewMonths(300, ""),
+		Method:     RepaymentInterestOnly,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(83333), io.InitialPayment.Amount)
+	assert.Equal(t, int64(20000000), io.Schedule[299].Principal.Amount)
+
+	_, err = m.Quote(MortgageQuoteRequest{
+		LoanAmount:    NewMoney("GBP", 20000000, ""),
+		PropertyValue: NewMoney("GBP", 22000000, ""),
+		Term:          NewMonths(300, ""),
+	})
+	assert.ErrorIs(t, err, ErrLoanToValueExceeded)
+
+	_, err = m.Quote(MortgageQuoteRequest{LoanAmount: NewMoney("GBP", 1000, ""), Term: NewMonths(300, "")})
+	assert.ErrorIs(t, err, ErrAmountOutOfRange)
+
+	_, err = m.Quote(MortgageQuoteRequest{LoanAmount: NewMoney("GBP", 20000000, ""), Term: NewMonths(480, "")})
+	assert.ErrorIs(t, err, ErrTermOutOfRange)
+}
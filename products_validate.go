@@ -0,0 +1,220 @@
+package capis
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type (
+	// FieldError is a single invalid field of a product, Field is the JSON
+	// name of the field, e.g. minimum_loan.amount.
+	FieldError struct {
+		Field   string
+		Message string
+	}
+
+	// ValidationError contains all of the invalid fields of a product.
+	ValidationError struct {
+		Errors []*FieldError
+	}
+
+	validator struct {
+		errs []*FieldError
+	}
+)
+
+// WithValidation returns an option to pass to New(), products are
+// validated before they are created or updated.
+func WithValidation() Option {
+	return func(c *Client) error {
+		c.validate = true
+		return nil
+	}
+}
+
+// validateProduct validates the product when WithValidation is set,
+// otherwise only AnnotationHighlightedProduct is validated. The enums are
+// checked in strict mode.
+func (c *Client) validateProduct(p interface{ Validate() error }, meta Metadata) error {
+	if err := c.checkEnums(p); err != nil {
+		return err
+	}
+	if c.validate {
+		return p.Validate()
+	}
+
+	v := &validator{}
+	v.highlighted("metadata", meta)
+	return v.err()
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "invalid product: " + strings.Join(msgs, "; ")
+}
+
+// Validate will check the request before it is sent.
+func (r *NewLoanRequest) Validate() error {
+	v := &validator{}
+	v.product(r.Issuer, r.Name, r.URLApply, r.URLLogo)
+	v.moneyRange("minimum_loan", "maximum_loan", r.MinimumLoan, r.MaximumLoan)
+	v.monthsRange("minimum_term", "maximum_term", r.MinimumTerm, r.MaximumTerm)
+	v.fee("monthly_fee", r.MonthlyFee)
+	v.fee("setup_fee", r.SetupFee)
+	v.metadata("metadata", r.Meta)
+	return v.err()
+}
+
+// Validate will check the loan before it is sent.
+func (l *Loan) Validate() error {
+	v := &validator{}
+	v.product(l.Issuer, l.Name, l.URLApply, l.URLLogo)
+	v.moneyRange("minimum_loan", "maximum_loan", l.MinimumLoan, l.MaximumLoan)
+	v.monthsRange("minimum_term", "maximum_term", l.MinimumTerm, l.MaximumTerm)
+	v.fee("monthly_fee", l.MonthlyFee)
+	v.fee("setup_fee", l.SetupFee)
+	v.metadata("metadata", l.Meta)
+	return v.err()
+}
+
+// Validate will check the request before it is sent.
+func (r *NewMortgageRequest) Validate() error {
+	v := &validator{}
+	v.product(r.Issuer, r.Name, r.URLApply, r.URLLogo)
+	v.moneyRange("minimum_loan", "maximum_loan", r.MinimumLoan, r.MaximumLoan)
+	v.monthsRange("minimum_term", "maximum_term", r.MinimumTerm, r.MaximumTerm)
+	v.percentage("loan_to_value", r.LoanToValue)
+	v.months("offer_interest_rate.period", r.OfferInterestRate.Period)
+	v.fee("fee", r.Fee)
+	v.fee("early_redemption_charge", r.EarlyRedemptionCharge)
+	v.metadata("metadata", r.Meta)
+	return v.err()
+}
+
+// Validate will check the mortgage before it is sent.
+func (m *Mortgage) Validate() error {
+	v := &validator{}
+	v.product(m.Issuer, m.Name, m.URLApply, m.URLLogo)
+	v.moneyRange("minimum_loan", "maximum_loan", m.MinimumLoan, m.MaximumLoan)
+	v.monthsRange("minimum_term", "maximum_term", m.MinimumTerm, m.MaximumTerm)
+	v.percentage("loan_to_value", m.LoanToValue)
+	v.months("offer_interest_rate.period", m.OfferInterestRate.Period)
+	v.fee("fee", m.Fee)
+	v.fee("early_redemption_charge", m.EarlyRedemptionCharge)
+	v.metadata("metadata", m.Meta)
+	return v.err()
+}
+
+// Validate will check the request before it is sent.
+func (r *NewBankAccountRequest) Validate() error {
+	v := &validator{}
+	v.product(r.Issuer, r.Name, r.URLApply, r.URLLogo)
+	v.moneyRange("deposit_minimum", "deposit_maximum", r.MinimumDeposit, r.MaximumDeposit)
+	v.months("offer_interest_rate.period", r.OfferInterestRate.Period)
+	v.money("annual_fee", r.AnnualFee)
+	v.money("monthly_fee", r.MonthlyFee)
+	v.metadata("metadata", r.Meta)
+	return v.err()
+}
+
+// Validate will check the bank account before it is sent.
+func (b *BankAccount) Validate() error {
+	v := &validator{}
+	v.product(b.Issuer, b.Name, b.URLApply, b.URLLogo)
+	v.moneyRange("deposit_minimum", "deposit_maximum", b.MinimumDeposit, b.MaximumDeposit)
+	v.months("offer_interest_rate.period", b.OfferInterestRate.Period)
+	v.money("annual_fee", b.AnnualFee)
+	v.money("monthly_fee", b.MonthlyFee)
+	v.metadata("metadata", b.Meta)
+	return v.err()
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errs}
+}
+
+func (v *validator) product(issuer, name, urlApply, urlLogo string) {
+	if strings.TrimSpace(issuer) == "" {
+		v.add("issuer", "is required")
+	}
+	if strings.TrimSpace(name) == "" {
+		v.add("name", "is required")
+	}
+	v.url("url_apply", urlApply)
+	v.url("url_logo", urlLogo)
+}
+
+func (v *validator) url(field, value string) {
+	if value == "" {
+		return
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(field, "%q is not an absolute http(s) url", value)
+	}
+}
+
+func (v *validator) money(field string, m Money) {
+	if m.Amount < 0 {
+		v.add(field+".amount", "must not be negative")
+	}
+}
+
+func (v *validator) moneyRange(minField, maxField string, min, max Money) {
+	v.money(minField, min)
+	v.money(maxField, max)
+
+	if min.Currency != "" && max.Currency != "" && min.Currency != max.Currency {
+		v.add(maxField+".currency", "%s does not match the %s currency %s", max.Currency, minField, min.Currency)
+		return
+	}
+	if max.Amount > 0 && min.Amount > max.Amount {
+		v.add(minField+".amount", "must not be more than the %s", maxField)
+	}
+}
+
+func (v *validator) months(field string, m Months) {
+	if m.Value < 0 {
+		v.add(field+".value", "must not be negative")
+	}
+}
+
+func (v *validator) monthsRange(minField, maxField string, min, max Months) {
+	v.months(minField, min)
+	v.months(maxField, max)
+
+	if max.Value > 0 && min.Value > max.Value {
+		v.add(minField+".value", "must not be more than the %s", maxField)
+	}
+}
+
+func (v *validator) percentage(field string, r Rate) {
+	if r.Value < 0 || r.Value > 100 {
+		v.add(field+".value", "must be between 0 and 100")
+	}
+}
+
+func (v *validator) fee(field string, f Fee) {
+	if f.Fixed != nil {
+		v.money(field+".fixed", *f.Fixed)
+	}
+	if f.Variable < 0 {
+		v.add(field+".variable", "must not be negative")
+	}
+}
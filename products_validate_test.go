@@ -0,0 +1,51 @@
+package capis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMortgageRequestValidate(t *testing.T) {
+	r := &NewMortgageRequest{
+		Name:        "Fixed 2 year",
+		URLApply:    "/apply",
+		MinimumLoan: NewMoney("GBP", 50000000, ""),
+		MaximumLoan: NewMoney("GBP", 100000, ""),
+		MinimumTerm: NewMonths(24, ""),
+		LoanToValue: NewRate(95, ""),
+		Fee:         NewFixedFee(&Money{Currency: "GBP", Amount: -1}, ""),
+	}
+
+	var verr *ValidationError
+	if assert.True(t, errors.As(r.Validate(), &verr)) {
+		fields := make([]string, len(verr.Errors))
+		for i, fe := range verr.Errors {
+			fields[i] = fe.Field
+		}
+		assert.Equal(t, []string{"issuer", "url_apply", "minimum_loan.amount", "fee.fixed.amount"}, fields)
+	}
+
+	r.MaximumLoan.Currency = "EUR"
+	assert.Contains(t, r.Validate().Error(), "maximum_loan.currency: EUR does not match the minimum_loan currency GBP")
+
+	assert.NoError(t, (&NewMortgageRequest{Issuer: "bank", Name: "Tracker"}).Validate())
+}
+
+func TestWithValidation(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer srv.Close()
+
+	c, _ := New(WithBase(srv.URL), WithAuthProvider(StaticToken("token")), WithValidation())
+
+	err := c.Products().NewLoan(context.Background(), &NewLoanRequest{Name: "Personal loan"})
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, 0, calls)
+}
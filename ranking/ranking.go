@@ -0,0 +1,102 @@
+// Package ranking orders products by comparable metrics such as rates,
+// fees and total cost.
+package ranking
+
+import (
+	"math"
+	"sort"
+
+	capis "lwebco.de/go-capis"
+)
+
+// Scorer returns the score of a product, lower scores rank first. Products
+// that cannot be scored should return math.Inf(1) to rank last.
+type Scorer[T any] func(T) float64
+
+// Descending returns a scorer that ranks higher scores first, products
+// that cannot be scored still rank last.
+func Descending[T any](s Scorer[T]) Scorer[T] {
+	return func(p T) float64 {
+		score := s(p)
+		if math.IsInf(score, 0) || math.IsNaN(score) {
+			return score
+		}
+		return -score
+	}
+}
+
+// Sort orders the products by the first scorer, later scorers break ties
+// and products that tie on every scorer keep their order. Pinned products
+// are placed before the rest and ordered amongst themselves the same way.
+func Sort[T any](products []T, pinned func(T) bool, scorers ...Scorer[T]) {
+	type scored struct {
+		product T
+		pinned  bool
+		scores  []float64
+	}
+
+	items := make([]scored, len(products))
+	for i, p := range products {
+		items[i] = scored{product: p, pinned: pinned != nil && pinned(p), scores: make([]float64, len(scorers))}
+		for j, s := range scorers {
+			items[i].scores[j] = s(p)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.pinned != b.pinned {
+			return a.pinned
+		}
+		for k := range a.scores {
+			if a.scores[k] != b.scores[k] {
+				return less(a.scores[k], b.scores[k])
+			}
+		}
+		return false
+	})
+
+	for i, it := range items {
+		products[i] = it.product
+	}
+}
+
+// less orders NaN scores after every other score.
+func less(a, b float64) bool {
+	if math.IsNaN(a) {
+		return false
+	}
+	if math.IsNaN(b) {
+		return true
+	}
+	return a < b
+}
+
+// highlighted reports if the product is annotated as highlighted.
+func highlighted[T capis.Product](p T) bool {
+	return p.GetMeta().IsHighlighted()
+}
+
+// Mortgages returns a copy of the mortgages ordered by the scorers with
+// highlighted mortgages pinned to the top.
+func Mortgages(mortgages []*capis.Mortgage, scorers ...Scorer[*capis.Mortgage]) []*capis.Mortgage {
+	out := append([]*capis.Mortgage(nil), mortgages...)
+	Sort(out, highlighted[*capis.Mortgage], scorers...)
+	return out
+}
+
+// Loans returns a copy of the loans ordered by the scorers with
+// highlighted loans pinned to the top.
+func Loans(loans []*capis.Loan, scorers ...Scorer[*capis.Loan]) []*capis.Loan {
+	out := append([]*capis.Loan(nil), loans...)
+	Sort(out, highlighted[*capis.Loan], scorers...)
+	return out
+}
+
+// BankAccounts returns a copy of the bank accounts ordered by the scorers
+// with highlighted bank accounts pinned to the top.
+func BankAccounts(accounts []*capis.BankAccount, scorers ...Scorer[*capis.BankAccount]) []*capis.BankAccount {
+	out := append([]*capis.BankAccount(nil), accounts...)
+	Sort(out, highlighted[*capis.BankAccount], scorers...)
+	return out
+}
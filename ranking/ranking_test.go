@@ -0,0 +1,48 @@
+package ranking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	capis "lwebco.de/go-capis"
+)
+
+func ids(ms []*capis.Mortgage) []string {
+	out := make([]string, len(ms))
+	for i, m := range ms {
+		out[i] = m.ID
+	}
+	return out
+}
+
+func TestMortgages(t *testing.T) {
+	ms := []*capis.Mortgage{
+		{ID: "a", OfferInterestRate: capis.NewRatePeriod(4.5, "", capis.NewMonths(24, "")), Fee: capis.NewVariableFee(1, "")},
+		{ID: "b", OfferInterestRate: capis.NewRatePeriod(3.9, "", capis.NewMonths(24, "")), Fee: capis.NewVariableFee(2, "")},
+		{ID: "c", OfferInterestRate: capis.NewRatePeriod(4.5, "", capis.NewMonths(24, "")), Fee: capis.NewVariableFee(0.5, "")},
+		{ID: "d", OfferInterestRate: capis.NewRatePeriod(5.1, "", capis.NewMonths(24, "")), Meta: capis.Metadata{
+			capis.AnnotationHighlightedProduct: "true",
+		}},
+		{ID: "e", OfferInterestRate: capis.NewRatePeriod(4.5, "", capis.NewMonths(24, "")), Fee: capis.NewVariableFee(1, "")},
+	}
+
+	assert.Equal(t, []string{"d", "b", "a", "c", "e"}, ids(Mortgages(ms, MortgageInitialRate())))
+
+	loan := capis.NewMoney("GBP", 20000000, "")
+	assert.Equal(t, []string{"d", "b", "c", "a", "e"}, ids(Mortgages(ms, MortgageInitialRate(), MortgageFees(loan))))
+	assert.Equal(t, []string{"d", "b", "a", "e", "c"}, ids(Mortgages(ms, Descending(MortgageFees(loan)))))
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, ids(ms), "input is not reordered")
+}
+
+func TestDescendingUnscoreable(t *testing.T) {
+	ms := []*capis.Mortgage{
+		{ID: "eur", Fee: capis.NewFixedFee(&capis.Money{Currency: "EUR", Amount: 1}, "")},
+		{ID: "low", Fee: capis.NewVariableFee(0.5, "")},
+		{ID: "high", Fee: capis.NewVariableFee(2, "")},
+	}
+
+	loan := capis.NewMoney("GBP", 20000000, "")
+	assert.Equal(t, []string{"high", "low", "eur"}, ids(Mortgages(ms, Descending(MortgageFees(loan)))))
+}
@@ -0,0 +1,93 @@
+package ranking
+
+import (
+	"math"
+
+	capis "lwebco.de/go-capis"
+)
+
+// MortgageInitialRate ranks mortgages by the lowest offer interest rate.
+func MortgageInitialRate() Scorer[*capis.Mortgage] {
+	return func(m *capis.Mortgage) float64 {
+		return m.OfferInterestRate.Value
+	}
+}
+
+// MortgageStandardRate ranks mortgages by the lowest standard interest rate.
+func MortgageStandardRate() Scorer[*capis.Mortgage] {
+	return func(m *capis.Mortgage) float64 {
+		return m.StandardInterestRate.Value
+	}
+}
+
+// MortgageFees ranks mortgages by the lowest fee for the loan amount.
+func MortgageFees(loan capis.Money) Scorer[*capis.Mortgage] {
+	return func(m *capis.Mortgage) float64 {
+		return feeScore(m.Fee, loan)
+	}
+}
+
+// MortgageOfferPeriodCost ranks mortgages by the lowest cost over the offer
+// period, mortgages that cannot be quoted for the borrowing rank last.
+func MortgageOfferPeriodCost(req capis.MortgageQuoteRequest) Scorer[*capis.Mortgage] {
+	return func(m *capis.Mortgage) float64 {
+		q, err := m.Quote(req)
+		if err != nil {
+			return math.Inf(1)
+		}
+		return float64(q.OfferPeriodCost.Amount)
+	}
+}
+
+// MortgageTrueCost ranks mortgages by the lowest true cost, mortgages that
+// cannot be quoted for the borrowing rank last.
+func MortgageTrueCost(req capis.MortgageQuoteRequest) Scorer[*capis.Mortgage] {
+	return func(m *capis.Mortgage) float64 {
+		q, err := m.Quote(req)
+		if err != nil {
+			return math.Inf(1)
+		}
+		return float64(q.TrueCost.Amount)
+	}
+}
+
+// LoanRate ranks loans by the lowest interest rate.
+func LoanRate() Scorer[*capis.Loan] {
+	return func(l *capis.Loan) float64 {
+		return l.InterestRate.Value
+	}
+}
+
+// LoanFees ranks loans by the lowest setup fee for the amount.
+func LoanFees(amount capis.Money) Scorer[*capis.Loan] {
+	return func(l *capis.Loan) float64 {
+		return feeScore(l.SetupFee, amount)
+	}
+}
+
+// LoanTotalRepayable ranks loans by the lowest total repayable, loans that
+// cannot be quoted for the amount and term rank last.
+func LoanTotalRepayable(amount capis.Money, term capis.Months) Scorer[*capis.Loan] {
+	return func(l *capis.Loan) float64 {
+		q, err := l.Quote(amount, term)
+		if err != nil {
+			return math.Inf(1)
+		}
+		return float64(q.TotalRepayable.Amount)
+	}
+}
+
+// BankAccountRate ranks bank accounts by the highest offer interest rate.
+func BankAccountRate() Scorer[*capis.BankAccount] {
+	return Descending(func(b *capis.BankAccount) float64 {
+		return b.OfferInterestRate.Value
+	})
+}
+
+func feeScore(fee capis.Fee, amount capis.Money) float64 {
+	cost, err := fee.Cost(amount)
+	if err != nil {
+		return math.Inf(1)
+	}
+	return float64(cost.Amount)
+}
@@ -0,0 +1,210 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// EndpointFamily groups the endpoints sharing a rate limit.
+type EndpointFamily string
+
+const (
+	// EndpointProducts covers the loan, mortgage and bank account endpoints.
+	EndpointProducts EndpointFamily = "products"
+	// EndpointEmbeds covers the embed endpoints.
+	EndpointEmbeds EndpointFamily = "embeds"
+	// EndpointIssuers covers the issuer endpoints.
+	EndpointIssuers EndpointFamily = "issuers"
+	// EndpointGroups covers the group endpoints.
+	EndpointGroups EndpointFamily = "groups"
+)
+
+// DefaultRateLimitRetries is the number of times a throttled request is
+// retried unless WithRateLimitRetries is given to New().
+const DefaultRateLimitRetries = 3
+
+// defaultRetryAfter is used when a throttled response has no Retry-After.
+const defaultRetryAfter = time.Second
+
+type (
+	rateLimiter struct {
+		mu          sync.Mutex
+		buckets     map[EndpointFamily]*rate.Limiter
+		pausedUntil map[EndpointFamily]time.Time
+		retries     int
+	}
+)
+
+var familyPrefixes = map[string]EndpointFamily{
+	"/v1/loans":        EndpointProducts,
+	"/v1/bankaccounts": EndpointProducts,
+	"/v2/mortgages":    EndpointProducts,
+	"/v1/embeds":       EndpointEmbeds,
+	"/v1/issuers":      EndpointIssuers,
+	"/v1/groups":       EndpointGroups,
+}
+
+// WithRateLimit returns an option to pass to New(), all requests share a
+// token bucket refilled with perSecond tokens holding up to burst tokens.
+// Throttled responses pause the bucket for the Retry-After duration and
+// are retried.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return WithEndpointRateLimit("", perSecond, burst)
+}
+
+// WithEndpointRateLimit returns an option to pass to New(), requests of
+// the family also wait on a token bucket of their own.
+func WithEndpointRateLimit(family EndpointFamily, perSecond float64, burst int) Option {
+	return func(c *Client) error {
+		c.rateLimiter().buckets[family] = rate.NewLimiter(rate.Limit(perSecond), burst)
+		return nil
+	}
+}
+
+// WithRateLimitRetries returns an option to pass to New(), throttled
+// requests are retried up to n times.
+func WithRateLimitRetries(n int) Option {
+	return func(c *Client) error {
+		c.rateLimiter().retries = n
+		return nil
+	}
+}
+
+func (c *Client) rateLimiter() *rateLimiter {
+	if c.limiter == nil {
+		c.limiter = &rateLimiter{
+			buckets:     map[EndpointFamily]*rate.Limiter{},
+			pausedUntil: map[EndpointFamily]time.Time{},
+			retries:     DefaultRateLimitRetries,
+		}
+	}
+	return c.limiter
+}
+
+// send will send the request waiting on the rate limits and retrying it
+// while it is throttled.
+func (c *Client) send(req *http.Request, ev *RequestEvent) (*http.Response, error) {
+	l := c.limiter
+	if l == nil {
+		return c.httpC.Do(req)
+	}
+
+	family := familyOfRoute(ev.Route)
+
+	for {
+		if err := l.wait(req.Context(), family); err != nil {
+			return nil, err
+		}
+
+		res, err := c.httpC.Do(req)
+		if err != nil || res.StatusCode != http.StatusTooManyRequests {
+			return res, err
+		}
+
+		wait := l.throttle(family, res.Header.Get("Retry-After"), time.Now())
+		if ev.Attempt > l.retries || !rewind(req) {
+			return res, nil
+		}
+		res.Body.Close()
+
+		ev.Attempt++
+		ev.Wait = wait
+		for _, o := range c.observers {
+			o.RequestRetried(req.Context(), ev)
+		}
+	}
+}
+
+func (l *rateLimiter) wait(ctx context.Context, family EndpointFamily) error {
+	families := []EndpointFamily{""}
+	if family != "" {
+		families = append(families, family)
+	}
+
+	for _, f := range families {
+		l.mu.Lock()
+		until, bucket := l.pausedUntil[f], l.buckets[f]
+		l.mu.Unlock()
+
+		if d := time.Until(until); d > 0 {
+			t := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			case <-t.C:
+			}
+		}
+
+		if bucket != nil {
+			if err := bucket.Wait(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// throttle pauses the bucket the request waited on until the time given
+// by Retry-After and returns how long it is paused for.
+func (l *rateLimiter) throttle(family EndpointFamily, retryAfter string, now time.Time) time.Duration {
+	wait := parseRetryAfter(retryAfter, now)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.buckets[family]; !ok {
+		family = ""
+	}
+	if until := now.Add(wait); until.After(l.pausedUntil[family]) {
+		l.pausedUntil[family] = until
+	}
+
+	return wait
+}
+
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if s, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+
+	return defaultRetryAfter
+}
+
+func familyOfRoute(route string) EndpointFamily {
+	for prefix, f := range familyPrefixes {
+		if route == prefix || strings.HasPrefix(route, prefix+"/") {
+			return f
+		}
+	}
+	return ""
+}
+
+// rewind will reset the body of the request so it can be sent again.
+func rewind(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+
+	return true
+}
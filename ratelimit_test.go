@@ -0,0 +1,60 @@
+package capis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitRetriesThrottledRequests(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c, _ := New(
+		WithBase(srv.URL),
+		WithAuthProvider(StaticToken("token")),
+		WithEndpointRateLimit(EndpointProducts, 100, 1),
+	)
+
+	err := c.Products().NewMortgage(context.Background(), &NewMortgageRequest{ID: "m1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRateLimitRespectsContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c, _ := New(
+		WithBase(srv.URL),
+		WithAuthProvider(StaticToken("token")),
+		WithRateLimit(0.1, 1),
+	)
+
+	assert.True(t, c.Healthy(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.False(t, c.Healthy(ctx))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("Sun, 01 Jan 2023 12:00:30 GMT", now))
+	assert.Equal(t, defaultRetryAfter, parseRetryAfter("", now))
+}
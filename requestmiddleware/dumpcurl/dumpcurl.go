@@ -1,6 +1,8 @@
 package dumpcurl
 
 import (
+	"bytes"
+	"io"
 	"net/http"
 
 	"github.com/moul/http2curl"
@@ -8,17 +10,170 @@ import (
 	capis "lwebco.de/go-capis"
 )
 
+type (
+	// Fields is the structured form of a dumped request, see WithFields.
+	Fields struct {
+		Method    string
+		URL       string
+		Headers   map[string]string
+		Body      string
+		Truncated bool
+		Curl      string
+	}
+
+	// Option customises the dump curl middleware.
+	Option func(*dumper)
+
+	readCloser struct {
+		io.Reader
+		io.Closer
+	}
+
+	dumper struct {
+		out           func(string)
+		fields        func(Fields)
+		redactHeaders map[string]bool
+		redactFields  map[string]bool
+		maskTokens    bool
+		maxBodySize   int
+	}
+)
+
 // New dump curl request middleware.
-func New(out func(string)) capis.RequestMiddlewareFunc {
-	return func(r *http.Request) *http.Request {
-		curl, err := http2curl.GetCurlCommand(r)
+//
+// Without options the full request is written to out, including the
+// Authorization header and the body, see WithSensitiveDefaults for a
+// configuration that is safe to use with shared logs.
+func New(out func(string), opts ...Option) capis.RequestMiddlewareFunc {
+	d := &dumper{
+		out:           out,
+		redactHeaders: map[string]bool{},
+		redactFields:  map[string]bool{},
+	}
 
-		if err != nil {
-			out("unable to create CURL command from request: " + err.Error())
-		} else {
-			out(curl.String())
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d.dump
+}
+
+// WithRedactedHeaders will replace the values of the headers with Redacted.
+func WithRedactedHeaders(names ...string) Option {
+	return func(d *dumper) {
+		for _, n := range names {
+			d.redactHeaders[http.CanonicalHeaderKey(n)] = true
 		}
+	}
+}
+
+// WithRedactedFields will replace the values of the JSON body fields with
+// Redacted, fields are matched by name at any depth of the body.
+func WithRedactedFields(names ...string) Option {
+	return func(d *dumper) {
+		for _, n := range names {
+			d.redactFields[n] = true
+		}
+	}
+}
+
+// WithTokenMasking will mask the token of the Authorization header leaving
+// only the scheme and the first and last few characters of the token.
+func WithTokenMasking() Option {
+	return func(d *dumper) {
+		d.maskTokens = true
+	}
+}
 
+// WithMaxBodySize will truncate dumped bodies to n bytes.
+func WithMaxBodySize(n int) Option {
+	return func(d *dumper) {
+		d.maxBodySize = n
+	}
+}
+
+// WithFields will emit the dumped request as Fields to out instead of
+// passing the curl command as a single string to the out func given to New.
+func WithFields(out func(Fields)) Option {
+	return func(d *dumper) {
+		d.fields = out
+	}
+}
+
+// WithSensitiveDefaults masks the bearer token, redacts cookies and the
+// commonly sensitive body fields.
+func WithSensitiveDefaults() Option {
+	return func(d *dumper) {
+		WithTokenMasking()(d)
+		WithRedactedHeaders("Cookie", "Set-Cookie", "Proxy-Authorization")(d)
+		WithRedactedFields("password", "token", "access_token", "refresh_token", "secret")(d)
+	}
+}
+
+func (d *dumper) dump(r *http.Request) *http.Request {
+	f, err := d.buildFields(r)
+	if err != nil {
+		d.write("unable to create CURL command from request: " + err.Error())
+		return r
+	}
+
+	if d.fields != nil {
+		d.fields(f)
 		return r
 	}
+
+	d.write(f.Curl)
+	return r
+}
+
+func (d *dumper) write(s string) {
+	if d.out != nil {
+		d.out(s)
+	}
+}
+
+func (d *dumper) buildFields(r *http.Request) (Fields, error) {
+	cp := r.Clone(r.Context())
+	cp.Header = d.headers(r.Header)
+
+	f := Fields{
+		Method:  r.Method,
+		URL:     r.URL.String(),
+		Headers: make(map[string]string, len(cp.Header)),
+	}
+
+	for k := range cp.Header {
+		f.Headers[k] = cp.Header.Get(k)
+	}
+
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			// Put back what was read so the transport still sends it and
+			// sees the same error from the rest of the body.
+			r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+			return f, err
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		body = d.redactBody(body)
+		if d.maxBodySize > 0 && len(body) > d.maxBodySize {
+			body = body[:d.maxBodySize]
+			f.Truncated = true
+		}
+
+		f.Body = string(body)
+		cp.Body = io.NopCloser(bytes.NewReader(body))
+	} else {
+		cp.Body = nil
+	}
+
+	curl, err := http2curl.GetCurlCommand(cp)
+	if err != nil {
+		return f, err
+	}
+	f.Curl = curl.String()
+
+	return f, nil
 }
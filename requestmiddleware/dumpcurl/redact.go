@@ -0,0 +1,95 @@
+package dumpcurl
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces the values of redacted headers and body fields.
+const Redacted = "[REDACTED]"
+
+// maskVisible is the number of characters left either side of a masked token.
+const maskVisible = 4
+
+func (d *dumper) headers(in http.Header) http.Header {
+	out := in.Clone()
+	if out == nil {
+		out = http.Header{}
+	}
+
+	for k, vs := range out {
+		switch {
+		case k == "Authorization" && d.maskTokens:
+			for i, v := range vs {
+				vs[i] = maskAuthorization(v)
+			}
+		case d.redactHeaders[k]:
+			for i := range vs {
+				vs[i] = Redacted
+			}
+		}
+	}
+
+	return out
+}
+
+func maskAuthorization(v string) string {
+	scheme, token, ok := strings.Cut(v, " ")
+	if !ok {
+		return maskToken(v)
+	}
+
+	return scheme + " " + maskToken(token)
+}
+
+func maskToken(t string) string {
+	if len(t) <= maskVisible*3 {
+		return strings.Repeat("*", len(t))
+	}
+
+	return t[:maskVisible] + "..." + t[len(t)-maskVisible:]
+}
+
+// redactBody replaces the configured fields of a JSON body, bodies which
+// are not JSON are returned unchanged.
+func (d *dumper) redactBody(body []byte) []byte {
+	if len(d.redactFields) == 0 {
+		return body
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+
+	out, err := json.Marshal(d.redactValue(v))
+	if err != nil {
+		return body
+	}
+
+	return out
+}
+
+func (d *dumper) redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range t {
+			if d.redactFields[k] {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = d.redactValue(fv)
+		}
+	case []interface{}:
+		for i, iv := range t {
+			t[i] = d.redactValue(iv)
+		}
+	}
+
+	return v
+}
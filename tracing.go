@@ -0,0 +1,103 @@
+package capis
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+)
+
+// Attribute keys set on the spans started by the client.
+const (
+	AttributeHTTPMethod     = "http.request.method"
+	AttributeHTTPStatusCode = "http.response.status_code"
+	AttributeURLFull        = "url.full"
+	AttributeURLTemplate    = "url.template"
+	AttributeServerAddress  = "server.address"
+	AttributeProductID      = "capis.product.id"
+	AttributeGroupID        = "capis.group.id"
+	AttributeEmbedID        = "capis.embed.id"
+	AttributeIssuerID       = "capis.issuer.id"
+)
+
+type (
+	// Tracer starts a span for each call made by the client.
+	Tracer interface {
+		Start(ctx context.Context, name string) (context.Context, Span)
+	}
+
+	// Span is a single traced call, values passed to SetAttribute are one
+	// of string, int, int64 or bool.
+	Span interface {
+		SetAttribute(key string, value interface{})
+		SetError(err error)
+		End()
+	}
+
+	openCensusTracer struct{}
+
+	openCensusSpan struct {
+		s *trace.Span
+	}
+
+	noopSpan struct{}
+
+	spanKey struct{}
+)
+
+// OpenCensusTracer is the tracer used unless WithTracer is given to New().
+var OpenCensusTracer Tracer = openCensusTracer{}
+
+// WithTracer returns an option to pass to New(), a nil tracer disables
+// tracing.
+func WithTracer(t Tracer) Option {
+	return func(c *Client) error {
+		c.tracer = t
+		return nil
+	}
+}
+
+func (c *Client) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	ctx, span := c.tracer.Start(ctx, name)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func spanFromContext(ctx context.Context) Span {
+	if s, ok := ctx.Value(spanKey{}).(Span); ok {
+		return s
+	}
+	return noopSpan{}
+}
+
+func (openCensusTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	ctx, span := trace.StartSpan(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &openCensusSpan{span}
+}
+
+func (s *openCensusSpan) SetAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.s.AddAttributes(trace.StringAttribute(key, v))
+	case int:
+		s.s.AddAttributes(trace.Int64Attribute(key, int64(v)))
+	case int64:
+		s.s.AddAttributes(trace.Int64Attribute(key, v))
+	case bool:
+		s.s.AddAttributes(trace.BoolAttribute(key, v))
+	}
+}
+
+func (s *openCensusSpan) SetError(err error) {
+	s.s.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+}
+
+func (s *openCensusSpan) End() {
+	s.s.End()
+}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+func (noopSpan) SetError(error)                   {}
+func (noopSpan) End()                             {}
@@ -26,7 +26,8 @@ type (
 		Description string `json:"description"`
 	}
 
-	// Money is a generic representation from capis.
+	// Money is a generic representation from capis, the Amount is in the
+	// ISO-4217 minor units of the currency, e.g. pence for GBP.
 	Money struct {
 		Currency    string `json:"currency"`
 		Amount      int64  `json:"amount"`